@@ -0,0 +1,338 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ADFNodeHandler renders an ADF node as plain text. Handlers are registered
+// by node type through ConverterConfig.ADFNodeHandlers and take precedence
+// over the built-in rendering, so macros, media, and extension nodes that
+// would otherwise render as empty can be given a text form.
+type ADFNodeHandler func(node map[string]any, sb *strings.Builder)
+
+// ADFListStyle controls how ADF list items are rendered. Empty fields use
+// the corresponding DefaultADFListStyle value.
+type ADFListStyle struct {
+	Bullet string // Prefix for bulletList items
+	Number string // fmt format for orderedList items, given the item number
+	Indent string // Indentation added per level of nesting
+}
+
+// DefaultADFListStyle renders lists as "- item" and "1. item", indenting
+// nested lists by two spaces.
+var DefaultADFListStyle = ADFListStyle{Bullet: "- ", Number: "%d. ", Indent: "  "}
+
+// adfRenderer converts ADF to plain text, consulting custom node handlers
+// before the built-in rendering. The zero value uses only the built-ins.
+type adfRenderer struct {
+	handlers map[string]ADFNodeHandler
+	lists    ADFListStyle
+}
+
+// extractText renders a string or ADF field value using r's handlers.
+// Any JSON-decoded value is accepted; malformed ADF yields best-effort text.
+func (r adfRenderer) extractText(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]any:
+		return r.render(v)
+	case []any:
+		// A bare content array without the enclosing doc node
+		return r.render(map[string]any{"type": "doc", "content": v})
+	}
+	return ""
+}
+
+// render extracts plain text from an ADF document.
+func (r adfRenderer) render(doc map[string]any) string {
+	var sb strings.Builder
+	r.extractTextFromNode(doc, &sb)
+	return strings.TrimSpace(sb.String())
+}
+
+func (r adfRenderer) extractTextFromNode(node map[string]any, sb *strings.Builder) {
+	nodeType, _ := node["type"].(string)
+
+	if handler, ok := r.handlers[nodeType]; ok {
+		handler(node, sb)
+		return
+	}
+
+	switch nodeType {
+	case "text":
+		if text, ok := adfScalarText(node["text"]); ok {
+			if adfMark(node, "code") != nil {
+				sb.WriteString("`" + text + "`")
+			} else {
+				sb.WriteString(text)
+			}
+			// Keep hyperlink targets as "text (url)"
+			if href := linkHref(node); href != "" && href != text {
+				sb.WriteString(" (" + href + ")")
+			}
+		}
+		return
+
+	case "hardBreak":
+		sb.WriteString("\n")
+		return
+
+	case "mention":
+		// Render as "@Display Name", falling back to the account ID
+		attrs, _ := node["attrs"].(map[string]any)
+		name, _ := attrs["text"].(string)
+		name = strings.TrimPrefix(name, "@")
+		if name == "" {
+			name, _ = attrs["id"].(string)
+		}
+		if name != "" {
+			sb.WriteString("@" + name)
+		}
+		return
+
+	case "tableRow":
+		// Render cells on a single line separated by pipes
+		ensureNewline(sb)
+		cells := make([]string, 0, len(adfChildren(node)))
+		for _, cell := range adfChildren(node) {
+			cells = append(cells, r.extractInlineText(cell))
+		}
+		sb.WriteString(strings.Join(cells, " | "))
+		sb.WriteString("\n")
+		return
+
+	case "panel":
+		// Prefix panel content with its type, e.g. "[info] ..."
+		ensureNewline(sb)
+		panelType := "info"
+		if attrs, ok := node["attrs"].(map[string]any); ok {
+			if pt, ok := attrs["panelType"].(string); ok && pt != "" {
+				panelType = pt
+			}
+		}
+		var inner strings.Builder
+		r.extractChildren(node, &inner)
+		sb.WriteString("[" + panelType + "] " + strings.TrimSpace(inner.String()))
+		sb.WriteString("\n")
+		return
+
+	case "codeBlock":
+		// Fence the code, keeping its language and internal newlines
+		ensureNewline(sb)
+		language := ""
+		if attrs, ok := node["attrs"].(map[string]any); ok {
+			language, _ = attrs["language"].(string)
+		}
+		var inner strings.Builder
+		r.extractChildren(node, &inner)
+		sb.WriteString("```" + language + "\n")
+		sb.WriteString(strings.TrimSuffix(inner.String(), "\n"))
+		sb.WriteString("\n```\n")
+		return
+
+	case "blockquote":
+		// Quote each line; nested blockquotes stack their prefixes
+		ensureNewline(sb)
+		var inner strings.Builder
+		r.extractChildren(node, &inner)
+		for _, line := range strings.Split(strings.TrimSpace(inner.String()), "\n") {
+			sb.WriteString(strings.TrimRight("> "+line, " ") + "\n")
+		}
+		return
+
+	case "rule":
+		ensureNewline(sb)
+		sb.WriteString("---\n")
+		return
+
+	case "bulletList", "orderedList":
+		ensureNewline(sb)
+		r.extractList(node, nodeType == "orderedList", sb)
+		return
+
+	case "paragraph", "heading", "listItem", "table":
+		// Add newlines for block elements
+		ensureNewline(sb)
+	}
+
+	r.extractChildren(node, sb)
+}
+
+// extractList renders each list item on its own line with a bullet or number
+// prefix. Continuation lines, including nested lists, are indented one level
+// so nesting depth is reflected in the output.
+func (r adfRenderer) extractList(node map[string]any, ordered bool, sb *strings.Builder) {
+	style := r.listStyle()
+
+	number := 1
+	if attrs, ok := node["attrs"].(map[string]any); ok {
+		if order, ok := attrs["order"].(float64); ok && order > 0 {
+			number = int(order)
+		}
+	}
+
+	for _, item := range adfChildren(node) {
+		prefix := style.Bullet
+		if ordered {
+			prefix = fmt.Sprintf(style.Number, number)
+			number++
+		}
+
+		var inner strings.Builder
+		r.extractChildren(item, &inner)
+		lines := strings.Split(strings.TrimSpace(inner.String()), "\n")
+
+		sb.WriteString(prefix + lines[0] + "\n")
+		for _, line := range lines[1:] {
+			sb.WriteString(style.Indent + line + "\n")
+		}
+	}
+}
+
+// listStyle returns the configured list style with defaults filled in.
+func (r adfRenderer) listStyle() ADFListStyle {
+	style := r.lists
+	if style.Bullet == "" {
+		style.Bullet = DefaultADFListStyle.Bullet
+	}
+	if style.Number == "" {
+		style.Number = DefaultADFListStyle.Number
+	}
+	if style.Indent == "" {
+		style.Indent = DefaultADFListStyle.Indent
+	}
+	return style
+}
+
+// linkHref returns the href of the first link mark on a text node, if any.
+func linkHref(node map[string]any) string {
+	mark := adfMark(node, "link")
+	if mark == nil {
+		return ""
+	}
+	attrs, _ := mark["attrs"].(map[string]any)
+	href, _ := attrs["href"].(string)
+	return href
+}
+
+// adfMark returns the first mark of the given type on a text node, or nil.
+func adfMark(node map[string]any, markType string) map[string]any {
+	marks, _ := node["marks"].([]any)
+	for _, m := range marks {
+		if mark, ok := m.(map[string]any); ok && mark["type"] == markType {
+			return mark
+		}
+	}
+	return nil
+}
+
+// extractChildren renders each child node of an ADF node in order.
+func (r adfRenderer) extractChildren(node map[string]any, sb *strings.Builder) {
+	for _, child := range adfChildren(node) {
+		r.extractTextFromNode(child, sb)
+	}
+}
+
+// extractInlineText renders a node's text on a single line, collapsing any
+// block-level line breaks into spaces. Used for table cells.
+func (r adfRenderer) extractInlineText(node map[string]any) string {
+	var sb strings.Builder
+	r.extractChildren(node, &sb)
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// adfChildren returns the child nodes from an ADF node's content array.
+// Malformed content is tolerated: a single node instead of an array is
+// treated as one child, and bare strings become text nodes, so no text is
+// silently dropped. Other values are skipped.
+func adfChildren(node map[string]any) []map[string]any {
+	var content []any
+	switch v := node["content"].(type) {
+	case []any:
+		content = v
+	case map[string]any, string:
+		content = []any{v}
+	default:
+		return nil
+	}
+
+	children := make([]map[string]any, 0, len(content))
+	for _, child := range content {
+		switch c := child.(type) {
+		case map[string]any:
+			children = append(children, c)
+		case string:
+			children = append(children, map[string]any{"type": "text", "text": c})
+		}
+	}
+	return children
+}
+
+// adfScalarText returns the text of a scalar JSON value. Well-formed ADF
+// text is always a string, but numbers and booleans are rendered rather
+// than dropped.
+func adfScalarText(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case float64, bool, json.Number:
+		return fmt.Sprint(v), true
+	}
+	return "", false
+}
+
+// ensureNewline starts a new line unless the output is empty or already at one.
+func ensureNewline(sb *strings.Builder) {
+	if sb.Len() > 0 && !strings.HasSuffix(sb.String(), "\n") {
+		sb.WriteString("\n")
+	}
+}
+
+// textToADF converts plain text into an ADF document for Jira Cloud, the
+// inverse of adfRenderer.render. Blank lines separate paragraphs and single
+// newlines within a paragraph become hardBreak nodes.
+func textToADF(s string) map[string]any {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+
+	content := []any{}
+	var lines []string
+	flush := func() {
+		if len(lines) > 0 {
+			content = append(content, adfParagraphFromLines(lines))
+			lines = nil
+		}
+	}
+
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+
+	return map[string]any{
+		"type":    "doc",
+		"version": 1,
+		"content": content,
+	}
+}
+
+// adfParagraphFromLines builds a paragraph node, joining lines with hardBreaks.
+func adfParagraphFromLines(lines []string) map[string]any {
+	inline := make([]any, 0, 2*len(lines)-1)
+	for i, line := range lines {
+		if i > 0 {
+			inline = append(inline, map[string]any{"type": "hardBreak"})
+		}
+		inline = append(inline, map[string]any{"type": "text", "text": line})
+	}
+	return map[string]any{
+		"type":    "paragraph",
+		"content": inline,
+	}
+}
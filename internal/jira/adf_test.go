@@ -0,0 +1,345 @@
+package jira
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// adfDoc builds an ADF document from block nodes.
+func adfDoc(content ...any) map[string]any {
+	return adfNode("doc", content...)
+}
+
+// adfNode builds an ADF node of the given type with child content.
+func adfNode(nodeType string, content ...any) map[string]any {
+	node := map[string]any{"type": nodeType}
+	if len(content) > 0 {
+		node["content"] = content
+	}
+	return node
+}
+
+// adfText builds an ADF text node.
+func adfText(text string) map[string]any {
+	return map[string]any{"type": "text", "text": text}
+}
+
+// adfParagraph builds an ADF paragraph containing a single text node.
+func adfParagraph(text string) map[string]any {
+	return adfNode("paragraph", adfText(text))
+}
+
+func TestExtractTextFromADF_Table(t *testing.T) {
+	doc := adfDoc(
+		adfParagraph("Results:"),
+		adfNode("table",
+			adfNode("tableRow",
+				adfNode("tableHeader", adfParagraph("Name")),
+				adfNode("tableHeader", adfParagraph("Value")),
+			),
+			adfNode("tableRow",
+				adfNode("tableCell", adfParagraph("alpha")),
+				adfNode("tableCell", adfParagraph("1"), adfParagraph("one")),
+			),
+		),
+		adfParagraph("After table"),
+	)
+
+	want := "Results:\nName | Value\nalpha | 1 one\nAfter table"
+	if got := (adfRenderer{}).render(doc); got != want {
+		t.Errorf("render() = %q, want %q", got, want)
+	}
+}
+
+func TestExtractTextFromADF_Panel(t *testing.T) {
+	doc := adfDoc(
+		adfParagraph("Intro"),
+		map[string]any{
+			"type":    "panel",
+			"attrs":   map[string]any{"panelType": "warning"},
+			"content": []any{adfParagraph("Careful here")},
+		},
+		adfNode("panel", adfParagraph("Default type")),
+	)
+
+	want := "Intro\n[warning] Careful here\n[info] Default type"
+	if got := (adfRenderer{}).render(doc); got != want {
+		t.Errorf("render() = %q, want %q", got, want)
+	}
+}
+
+func TestExtractTextFromADF_HardBreak(t *testing.T) {
+	doc := adfDoc(
+		adfNode("paragraph", adfText("line one"), adfNode("hardBreak"), adfText("line two")),
+	)
+
+	want := "line one\nline two"
+	if got := (adfRenderer{}).render(doc); got != want {
+		t.Errorf("render() = %q, want %q", got, want)
+	}
+}
+
+func TestExtractTextFromADF_Link(t *testing.T) {
+	linked := func(text, href string) map[string]any {
+		node := adfText(text)
+		node["marks"] = []any{
+			map[string]any{"type": "strong"},
+			map[string]any{"type": "link", "attrs": map[string]any{"href": href}},
+		}
+		return node
+	}
+
+	doc := adfDoc(
+		adfNode("paragraph",
+			adfText("See "),
+			linked("the docs", "https://example.com/docs"),
+			adfText(" or "),
+			linked("https://example.com", "https://example.com"),
+		),
+	)
+
+	want := "See the docs (https://example.com/docs) or https://example.com"
+	if got := (adfRenderer{}).render(doc); got != want {
+		t.Errorf("render() = %q, want %q", got, want)
+	}
+}
+
+func TestTextToADF(t *testing.T) {
+	doc := textToADF("First paragraph\nsecond line\n\n\nSecond paragraph\r\n")
+
+	if doc["type"] != "doc" || doc["version"] != 1 {
+		t.Fatalf("textToADF() root = %v, want doc version 1", doc)
+	}
+
+	want := []any{
+		map[string]any{"type": "paragraph", "content": []any{
+			map[string]any{"type": "text", "text": "First paragraph"},
+			map[string]any{"type": "hardBreak"},
+			map[string]any{"type": "text", "text": "second line"},
+		}},
+		map[string]any{"type": "paragraph", "content": []any{
+			map[string]any{"type": "text", "text": "Second paragraph"},
+		}},
+	}
+	if !reflect.DeepEqual(doc["content"], want) {
+		t.Errorf("textToADF() content = %#v, want %#v", doc["content"], want)
+	}
+
+	// Round trip back through the extractor
+	if got := (adfRenderer{}).render(doc); got != "First paragraph\nsecond line\nSecond paragraph" {
+		t.Errorf("render(textToADF()) = %q", got)
+	}
+}
+
+func TestTextToADF_Empty(t *testing.T) {
+	doc := textToADF("  \n\n")
+	content, ok := doc["content"].([]any)
+	if !ok || len(content) != 0 {
+		t.Errorf("textToADF(blank) content = %#v, want empty slice", doc["content"])
+	}
+}
+
+func TestConverter_ADFNodeHandlers(t *testing.T) {
+	media := func(name string) map[string]any {
+		return adfNode("mediaSingle", map[string]any{
+			"type":  "media",
+			"attrs": map[string]any{"type": "file", "alt": name},
+		})
+	}
+	doc := adfDoc(
+		adfParagraph("Screenshot:"),
+		media("crash.png"),
+		adfNode("paragraph", adfText("State: "), map[string]any{
+			"type":  "status",
+			"attrs": map[string]any{"text": "IN REVIEW"},
+		}),
+	)
+
+	// Unregistered node types render as before
+	if got, want := (adfRenderer{}).render(doc), "Screenshot:\nState:"; got != want {
+		t.Errorf("render() = %q, want %q", got, want)
+	}
+
+	converter := NewConverter(ConverterConfig{
+		JiraURL: "https://test.atlassian.net",
+		ADFNodeHandlers: map[string]ADFNodeHandler{
+			"mediaSingle": func(node map[string]any, sb *strings.Builder) {
+				for _, child := range adfChildren(node) {
+					attrs, _ := child["attrs"].(map[string]any)
+					name, _ := attrs["alt"].(string)
+					sb.WriteString("\n[attachment: " + name + "]\n")
+				}
+			},
+			"status": func(node map[string]any, sb *strings.Builder) {
+				attrs, _ := node["attrs"].(map[string]any)
+				text, _ := attrs["text"].(string)
+				sb.WriteString(text)
+			},
+		},
+	})
+
+	issue, err := converter.ConvertOne(&JiraIssue{Key: "PROJ-1", Fields: JiraIssueFields{Description: doc}})
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+	want := "Screenshot:\n[attachment: crash.png]\nState: IN REVIEW"
+	if issue.Description != want {
+		t.Errorf("Description = %q, want %q", issue.Description, want)
+	}
+}
+
+func TestExtractTextFromADF_NestedLists(t *testing.T) {
+	doc := adfDoc(
+		adfParagraph("Steps:"),
+		adfNode("bulletList",
+			adfNode("listItem",
+				adfParagraph("Prepare"),
+				adfNode("orderedList",
+					adfNode("listItem", adfParagraph("Check out")),
+					adfNode("listItem", adfParagraph("Build")),
+				),
+			),
+			adfNode("listItem", adfParagraph("Deploy")),
+		),
+		adfParagraph("Done."),
+	)
+
+	want := "Steps:\n- Prepare\n  1. Check out\n  2. Build\n- Deploy\nDone."
+	if got := (adfRenderer{}).render(doc); got != want {
+		t.Errorf("render() = %q, want %q", got, want)
+	}
+
+	converter := NewConverter(ConverterConfig{
+		JiraURL:      "https://test.atlassian.net",
+		ADFListStyle: ADFListStyle{Bullet: "* ", Number: "%d) ", Indent: "    "},
+	})
+	issue, err := converter.ConvertOne(&JiraIssue{Key: "PROJ-1", Fields: JiraIssueFields{Description: doc}})
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+	want = "Steps:\n* Prepare\n    1) Check out\n    2) Build\n* Deploy\nDone."
+	if issue.Description != want {
+		t.Errorf("Description = %q, want %q", issue.Description, want)
+	}
+}
+
+func TestExtractTextFromADF_OrderedListStart(t *testing.T) {
+	list := adfNode("orderedList", adfNode("listItem", adfParagraph("third")), adfNode("listItem", adfParagraph("fourth")))
+	list["attrs"] = map[string]any{"order": float64(3)}
+
+	want := "3. third\n4. fourth"
+	if got := (adfRenderer{}).render(adfDoc(list)); got != want {
+		t.Errorf("render() = %q, want %q", got, want)
+	}
+}
+
+func TestExtractTextFromADF_Mention(t *testing.T) {
+	mention := func(attrs map[string]any) map[string]any {
+		return map[string]any{"type": "mention", "attrs": attrs}
+	}
+	doc := adfDoc(
+		adfNode("paragraph",
+			adfText("Assigned to "),
+			mention(map[string]any{"id": "5b10a2844c20165700ede21g", "text": "@Alice Smith"}),
+			adfText(", cc "),
+			mention(map[string]any{"id": "5b10ac8d82e05b22cc7d4ef5"}),
+		),
+	)
+
+	want := "Assigned to @Alice Smith, cc @5b10ac8d82e05b22cc7d4ef5"
+	if got := (adfRenderer{}).render(doc); got != want {
+		t.Errorf("render() = %q, want %q", got, want)
+	}
+}
+
+func TestExtractTextFromADF_Code(t *testing.T) {
+	codeBlock := adfNode("codeBlock", adfText("func main() {\n\tfmt.Println(\"hi\")\n}"))
+	codeBlock["attrs"] = map[string]any{"language": "go"}
+
+	inline := adfText("go test ./...")
+	inline["marks"] = []any{map[string]any{"type": "code"}}
+
+	doc := adfDoc(
+		adfNode("paragraph", adfText("Run "), inline, adfText(" first.")),
+		codeBlock,
+		adfNode("codeBlock", adfText("plain")),
+		adfParagraph("Done"),
+	)
+
+	want := "Run `go test ./...` first.\n```go\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n```\n```\nplain\n```\nDone"
+	if got := (adfRenderer{}).render(doc); got != want {
+		t.Errorf("render() = %q, want %q", got, want)
+	}
+}
+
+// malformedADF holds JSON-decoded ADF shapes that violate the schema.
+var malformedADF = []struct {
+	name string
+	json string
+	want string
+}{
+	{"string content", `{"type": "doc", "content": "plain text"}`, "plain text"},
+	{"single node content", `{"type": "doc", "content": {"type": "paragraph", "content": [{"type": "text", "text": "one"}]}}`, "one"},
+	{"numeric text", `{"type": "doc", "content": [{"type": "paragraph", "content": [{"type": "text", "text": 42}]}]}`, "42"},
+	{"mixed content items", `{"type": "doc", "content": [null, 7, "loose", {"type": "paragraph", "content": [{"type": "text", "text": "kept"}]}]}`, "loose\nkept"},
+	{"bare content array", `[{"type": "paragraph", "content": [{"type": "text", "text": "no doc"}]}]`, "no doc"},
+	{"wrong attrs types", `{"type": "doc", "content": [
+		{"type": "orderedList", "attrs": "bad", "content": [{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "a"}]}]}]},
+		{"type": "codeBlock", "attrs": {"language": 5}, "content": [{"type": "text", "text": "x"}]},
+		{"type": "mention", "attrs": {"id": 1, "text": []}},
+		{"type": "panel", "attrs": {"panelType": null}}
+	]}`, "1. a\n```\nx\n```\n[info]"},
+	{"wrong marks types", `{"type": "doc", "content": [{"type": "text", "text": "t", "marks": [{"type": {}}, "code", {"type": "link", "attrs": {"href": 3}}]}]}`, "t"},
+	{"missing and odd types", `{"content": [{"type": 1, "text": "x"}, {"type": "tableRow", "content": [1, {"type": "tableCell"}]}, {"type": "hardBreak", "content": false}]}`, ""},
+	{"number", `3.5`, ""},
+	{"null", `null`, ""},
+}
+
+func TestExtractText_MalformedADF(t *testing.T) {
+	for _, tt := range malformedADF {
+		t.Run(tt.name, func(t *testing.T) {
+			var value any
+			if err := json.Unmarshal([]byte(tt.json), &value); err != nil {
+				t.Fatalf("invalid test JSON: %v", err)
+			}
+			if got := extractText(value); got != tt.want {
+				t.Errorf("extractText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func FuzzExtractText(f *testing.F) {
+	for _, tt := range malformedADF {
+		f.Add(tt.json)
+	}
+	f.Add(`{"type": "doc", "content": [{"type": "bulletList", "content": [{"type": "listItem", "content": [{"type": "bulletList"}]}]}]}`)
+
+	f.Fuzz(func(t *testing.T, data string) {
+		var value any
+		if err := json.Unmarshal([]byte(data), &value); err != nil {
+			return
+		}
+		// Must not panic on any decoded shape
+		_ = extractText(value)
+	})
+}
+
+func TestExtractTextFromADF_BlockquoteAndRule(t *testing.T) {
+	doc := adfDoc(
+		adfParagraph("Reported:"),
+		adfNode("blockquote",
+			adfParagraph("It crashes on save."),
+			adfParagraph("Every time."),
+			adfNode("blockquote", adfParagraph("Nested quote")),
+		),
+		adfNode("rule"),
+	)
+
+	want := "Reported:\n> It crashes on save.\n> Every time.\n> > Nested quote\n---"
+	if got := (adfRenderer{}).render(doc); got != want {
+		t.Errorf("render() = %q, want %q", got, want)
+	}
+}
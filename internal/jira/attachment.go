@@ -0,0 +1,75 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// JiraAttachment represents attachment metadata on a Jira issue.
+type JiraAttachment struct {
+	ID       string    `json:"id"`
+	Filename string    `json:"filename"`
+	Size     int64     `json:"size"`
+	MimeType string    `json:"mimeType"`
+	Author   *JiraUser `json:"author"`
+	Created  string    `json:"created"`
+	Content  string    `json:"content"` // URL to download the attachment content
+}
+
+// GetAttachments fetches attachment metadata for an issue.
+// Only metadata is returned; attachment content is not downloaded.
+func (c *Client) GetAttachments(ctx context.Context, issueKey string) ([]*JiraAttachment, error) {
+	endpoint := c.apiPath(fmt.Sprintf("/issue/%s?fields=attachment", url.PathEscape(issueKey)))
+
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, c.handleAPIError(resp.StatusCode, body)
+	}
+
+	var result JiraIssue
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return result.Fields.Attachments, nil
+}
+
+// DownloadAttachment streams the content of an attachment, given its
+// JiraAttachment.Content URL, to w and returns the number of bytes written.
+// The URL must belong to the configured Jira instance so credentials are
+// never sent elsewhere. The request timeout covers the whole download, so
+// raise Config.RequestTimeout for large files.
+func (c *Client) DownloadAttachment(ctx context.Context, contentURL string, w io.Writer) (int64, error) {
+	endpoint, ok := strings.CutPrefix(contentURL, c.baseURL)
+	if !ok || !strings.HasPrefix(endpoint, "/") {
+		return 0, fmt.Errorf("attachment URL %q is not on %s", contentURL, c.baseURL)
+	}
+
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, c.handleAPIError(resp.StatusCode, body)
+	}
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("downloading attachment: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,111 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const twoAttachmentsJSON = `{"key": "PROJ-1", "fields": {"attachment": [
+	{"id": "100", "filename": "screenshot.png", "size": 2048, "mimeType": "image/png",
+		"author": {"displayName": "Alice"}, "created": "2024-01-15T10:30:00.000+0000",
+		"content": "https://jira.example.com/rest/api/3/attachment/content/100"},
+	{"id": "101", "filename": "logs.txt", "size": 512, "mimeType": "text/plain",
+		"author": {"displayName": "Bob"}, "content": "https://jira.example.com/rest/api/3/attachment/content/101"}
+]}}`
+
+func TestGetAttachments(t *testing.T) {
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/rest/api/3/issue/PROJ-1" || req.URL.Query().Get("fields") != "attachment" {
+			t.Errorf("request = %s, want /rest/api/3/issue/PROJ-1?fields=attachment", req.URL)
+		}
+		return jsonResponse(http.StatusOK, twoAttachmentsJSON), nil
+	})
+
+	attachments, err := client.GetAttachments(context.Background(), "PROJ-1")
+	if err != nil {
+		t.Fatalf("GetAttachments() error = %v", err)
+	}
+	if len(attachments) != 2 {
+		t.Fatalf("GetAttachments() returned %d, want 2", len(attachments))
+	}
+
+	first := attachments[0]
+	if first.Filename != "screenshot.png" || first.Size != 2048 || first.MimeType != "image/png" {
+		t.Errorf("attachments[0] = %+v", first)
+	}
+	if first.Author.GetDisplayName() != "Alice" {
+		t.Errorf("attachments[0].Author = %q, want Alice", first.Author.GetDisplayName())
+	}
+	if first.Content != "https://jira.example.com/rest/api/3/attachment/content/100" {
+		t.Errorf("attachments[0].Content = %q", first.Content)
+	}
+	if attachments[1].Filename != "logs.txt" || attachments[1].Size != 512 {
+		t.Errorf("attachments[1] = %+v", attachments[1])
+	}
+}
+
+func TestConverter_Attachments(t *testing.T) {
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net"})
+
+	issues, err := converter.Convert([]*JiraIssue{
+		{Key: "PROJ-1", Fields: JiraIssueFields{Summary: "With files", Attachments: []*JiraAttachment{
+			{Filename: "screenshot.png"},
+			{Filename: "logs.txt"},
+		}}},
+		{Key: "PROJ-2", Fields: JiraIssueFields{Summary: "No files"}},
+	})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	if issues[0].AttachmentCount != 2 {
+		t.Errorf("AttachmentCount = %d, want 2", issues[0].AttachmentCount)
+	}
+	if len(issues[0].Attachments) != 2 || issues[0].Attachments[0] != "screenshot.png" || issues[0].Attachments[1] != "logs.txt" {
+		t.Errorf("Attachments = %v, want [screenshot.png logs.txt]", issues[0].Attachments)
+	}
+	if issues[1].AttachmentCount != 0 || issues[1].Attachments != nil {
+		t.Errorf("issue without attachments = %d %v", issues[1].AttachmentCount, issues[1].Attachments)
+	}
+}
+
+func TestDownloadAttachment(t *testing.T) {
+	payload := []byte("\x89PNG\r\n\x1a\nfake image bytes")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			t.Error("request missing Authorization header")
+		}
+		switch r.URL.Path {
+		case "/rest/api/3/attachment/content/100":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write(payload)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+
+	var buf bytes.Buffer
+	n, err := client.DownloadAttachment(context.Background(), server.URL+"/rest/api/3/attachment/content/100", &buf)
+	if err != nil {
+		t.Fatalf("DownloadAttachment() error = %v", err)
+	}
+	if n != int64(len(payload)) || !bytes.Equal(buf.Bytes(), payload) {
+		t.Errorf("DownloadAttachment() wrote %d bytes %q, want %q", n, buf.Bytes(), payload)
+	}
+
+	_, err = client.DownloadAttachment(context.Background(), server.URL+"/rest/api/3/attachment/content/999", io.Discard)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing attachment error = %v, want ErrNotFound", err)
+	}
+
+	if _, err := client.DownloadAttachment(context.Background(), "https://elsewhere.example.com/file", io.Discard); err == nil {
+		t.Error("DownloadAttachment() to another host succeeded, want error")
+	}
+}
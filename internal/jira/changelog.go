@@ -0,0 +1,79 @@
+package jira
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// JiraChangelog is the issue history returned when searching with
+// expand=changelog.
+type JiraChangelog struct {
+	Histories []*JiraChangelogHistory `json:"histories"`
+}
+
+// JiraChangelogHistory is a single change set: one author, one timestamp,
+// one or more field changes.
+type JiraChangelogHistory struct {
+	ID      string               `json:"id"`
+	Author  *JiraUser            `json:"author"`
+	Created string               `json:"created"`
+	Items   []*JiraChangelogItem `json:"items"`
+}
+
+// JiraChangelogItem describes the change to one field.
+type JiraChangelogItem struct {
+	Field      string `json:"field"`
+	FieldType  string `json:"fieldtype"`
+	From       string `json:"from"`
+	FromString string `json:"fromString"`
+	To         string `json:"to"`
+	ToString   string `json:"toString"`
+}
+
+// extractStatusEvents converts the status transitions in an issue's changelog
+// into bd audit events for the issue with the given bd ID. Jira status names
+// are mapped through mapStatus. Events are returned oldest first.
+func (c *Converter) extractStatusEvents(jira *JiraIssue, issueID string) []*types.Event {
+	if jira.Changelog == nil {
+		return nil
+	}
+
+	var events []*types.Event
+	for _, history := range jira.Changelog.Histories {
+		if history == nil {
+			continue
+		}
+		createdAt, err := parseJiraTimestamp(history.Created)
+		if err != nil {
+			continue
+		}
+		actor := ""
+		if history.Author != nil {
+			actor = history.Author.GetDisplayName()
+		}
+
+		for _, item := range history.Items {
+			if item == nil || !strings.EqualFold(item.Field, "status") {
+				continue
+			}
+			oldStatus := string(c.mapStatus(&JiraStatus{Name: item.FromString}))
+			newStatus := string(c.mapStatus(&JiraStatus{Name: item.ToString}))
+			events = append(events, &types.Event{
+				IssueID:   issueID,
+				EventType: types.EventStatusChanged,
+				Actor:     actor,
+				OldValue:  &oldStatus,
+				NewValue:  &newStatus,
+				CreatedAt: createdAt,
+			})
+		}
+	}
+
+	// Jira does not guarantee history order
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+	return events
+}
@@ -0,0 +1,84 @@
+package jira
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestConverter_StatusEventsFromChangelog(t *testing.T) {
+	raw := `{
+		"key": "PROJ-1",
+		"fields": {
+			"summary": "Ship it",
+			"status": {"name": "Done"},
+			"created": "2024-01-01T09:00:00.000+0000",
+			"updated": "2024-01-03T09:00:00.000+0000"
+		},
+		"changelog": {
+			"histories": [
+				{
+					"id": "2",
+					"author": {"displayName": "Bob"},
+					"created": "2024-01-03T09:00:00.000+0000",
+					"items": [
+						{"field": "status", "fromString": "In Progress", "toString": "Done"}
+					]
+				},
+				{
+					"id": "1",
+					"author": {"displayName": "Alice"},
+					"created": "2024-01-02T09:00:00.000+0000",
+					"items": [
+						{"field": "assignee", "fromString": "", "toString": "Alice"},
+						{"field": "status", "fromString": "To Do", "toString": "In Progress"}
+					]
+				}
+			]
+		}
+	}`
+
+	var jira JiraIssue
+	if err := json.Unmarshal([]byte(raw), &jira); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net", Prefix: "bd"})
+	result, err := converter.ConvertWithDependencies([]*JiraIssue{&jira})
+	if err != nil {
+		t.Fatalf("ConvertWithDependencies() error = %v", err)
+	}
+
+	if len(result.Events) != 2 {
+		t.Fatalf("got %d events, want 2", len(result.Events))
+	}
+
+	want := []struct {
+		actor    string
+		from, to types.Status
+		at       time.Time
+	}{
+		{"Alice", types.StatusOpen, types.StatusInProgress, time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)},
+		{"Bob", types.StatusInProgress, types.StatusClosed, time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC)},
+	}
+	for i, w := range want {
+		ev := result.Events[i]
+		if ev.IssueID != "bd-1" || ev.EventType != types.EventStatusChanged {
+			t.Errorf("event %d = %s/%s, want bd-1/%s", i, ev.IssueID, ev.EventType, types.EventStatusChanged)
+		}
+		if ev.Actor != w.actor {
+			t.Errorf("event %d Actor = %q, want %q", i, ev.Actor, w.actor)
+		}
+		if ev.OldValue == nil || *ev.OldValue != string(w.from) {
+			t.Errorf("event %d OldValue = %v, want %s", i, ev.OldValue, w.from)
+		}
+		if ev.NewValue == nil || *ev.NewValue != string(w.to) {
+			t.Errorf("event %d NewValue = %v, want %s", i, ev.NewValue, w.to)
+		}
+		if !ev.CreatedAt.Equal(w.at) {
+			t.Errorf("event %d CreatedAt = %v, want %v", i, ev.CreatedAt, w.at)
+		}
+	}
+}
@@ -3,6 +3,7 @@
 package jira
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
@@ -11,26 +12,163 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
+// Retry defaults for transient API failures (HTTP 429 and 503).
+// MaxRetryAfter caps the wait a server's Retry-After header can ask for.
+const (
+	DefaultMaxRetries     = 3
+	DefaultRetryBaseDelay = time.Second
+	MaxRetryAfter         = 2 * time.Minute
+)
+
+// DefaultRequestTimeout bounds a single HTTP attempt unless Config.RequestTimeout
+// overrides it.
+const DefaultRequestTimeout = 30 * time.Second
+
+// Default JQL clauses for the "open" and "closed" search states.
+const (
+	DefaultOpenStatusFilter   = "status != Done AND status != Closed"
+	DefaultClosedStatusFilter = "(status = Done OR status = Closed)"
+)
+
+// DefaultUserAgent identifies requests unless Config.UserAgent is set.
+const DefaultUserAgent = "bd-jira/1.0"
+
+// DefaultOrderBy orders generated project queries so pagination and
+// re-imports see issues in a stable order.
+const DefaultOrderBy = "created ASC"
+
+// DefaultAPIVersion is the Jira REST API version used unless Config.APIVersion
+// overrides it.
+const DefaultAPIVersion = "3"
+
+// DefaultConcurrency is the default number of search pages fetched in parallel.
+const DefaultConcurrency = 4
+
+// searchPageSize is the maxResults requested per search page.
+const searchPageSize = 100
+
+// keyBatchSize is the most issue keys placed in a single "key in (...)" query,
+// keeping the JQL well under Jira's length limits.
+const keyBatchSize = 100
+
 // Client provides methods to interact with Jira REST API.
 type Client struct {
-	baseURL    string
-	project    string
-	username   string
-	apiToken   string
-	httpClient *http.Client
-	isCloud    bool
+	baseURL        string
+	project        string
+	username       string
+	apiToken       string
+	accessToken    string
+	httpClient     *http.Client
+	isCloud        bool
+	maxRetries     int
+	retryBaseDelay time.Duration
+	concurrency    int
+	requestTimeout time.Duration
+	location       *time.Location
+	apiVersion     string
+	openFilter     string
+	closedFilter   string
+	orderBy        string
+	throttle       *requestThrottle
+	logger         RequestLogger
+	resolveUsers   bool
+	progress       func(fetched, total int)
+	userAgent      string
 }
 
+// RequestLogger is called after every HTTP attempt, including retries, with
+// the request method and URL, the response status (0 if no response was
+// received), and how long the attempt took. It never receives headers, so
+// credentials are not exposed.
+type RequestLogger func(method, url string, status int, dur time.Duration)
+
 // Config holds the Jira client configuration.
 type Config struct {
 	URL      string // Jira instance URL (e.g., https://company.atlassian.net)
 	Project  string // Jira project key (e.g., PROJ)
 	Username string // Username (email for Cloud, username for Server)
 	APIToken string // API token (Cloud) or PAT/password (Server)
+
+	// AccessToken is an OAuth 2.0 access token. When set, it is sent as a
+	// Bearer token and takes precedence over Username/APIToken.
+	AccessToken string
+
+	// MaxRetries is the number of times a 429 or 503 response is retried.
+	// Zero uses DefaultMaxRetries; a negative value disables retries.
+	MaxRetries int
+	// RetryBaseDelay is the initial backoff delay, doubled on each attempt.
+	// It is only used when the response has no Retry-After header.
+	// Zero uses DefaultRetryBaseDelay.
+	RetryBaseDelay time.Duration
+
+	// Concurrency bounds how many search pages are fetched in parallel once
+	// the total result count is known. Zero uses DefaultConcurrency; 1
+	// fetches pages sequentially.
+	Concurrency int
+
+	// HTTPClient, if set, is used for all requests. Use it to supply a custom
+	// transport for proxies, mTLS, or tests. Any Timeout set on it still
+	// applies in addition to RequestTimeout.
+	HTTPClient *http.Client
+
+	// RequestTimeout bounds each HTTP attempt, including reading the
+	// response body. A deadline on the caller's context also applies; the
+	// tighter of the two wins. Zero uses DefaultRequestTimeout; a negative
+	// value disables the per-request timeout.
+	RequestTimeout time.Duration
+
+	// APIVersion is the REST API version, "2" or "3". Defaults to
+	// DefaultAPIVersion. Older Server/DC releases without v3 need "2", which
+	// also sends descriptions as plain text instead of ADF.
+	APIVersion string
+
+	// MinRequestInterval spaces consecutive requests, including retries and
+	// parallel page fetches, at least this far apart to stay under Jira's
+	// rate limits. Zero disables throttling.
+	MinRequestInterval time.Duration
+
+	// OpenStatusFilter and ClosedStatusFilter replace the JQL clauses used
+	// for the "open" and "closed" search states, for workflows whose
+	// terminal statuses are not Done/Closed, e.g.
+	// `status NOT IN ("Shipped", "Abandoned")`. Default to
+	// DefaultOpenStatusFilter and DefaultClosedStatusFilter.
+	OpenStatusFilter   string
+	ClosedStatusFilter string
+
+	// OrderBy is the ORDER BY clause, without the keywords, appended to
+	// generated queries (e.g. "updated DESC"). Explicit JQL passed to a
+	// search is used as is, keeping any ORDER BY it contains. Defaults to
+	// DefaultOrderBy.
+	OrderBy string
+
+	// Location is the time zone of the Jira user's profile, used when
+	// formatting dates in JQL. Defaults to UTC.
+	Location *time.Location
+
+	// ResolveAccountIDs looks up display names for assignees and reporters
+	// that Jira returns with only an accountId, as GDPR-strict Cloud sites
+	// do, using a batched /user/bulk request after each search.
+	ResolveAccountIDs bool
+
+	// ProgressFunc, if set, is called after each search page with the
+	// number of issues fetched so far and the total Jira reports. Total is
+	// zero when Jira does not report one (token-paged Cloud searches) and
+	// never less than fetched, even if issues are added mid-search.
+	ProgressFunc func(fetched, total int)
+
+	// UserAgent is sent with every request so Jira admins can attribute
+	// traffic to a tool and version. Defaults to DefaultUserAgent.
+	UserAgent string
+
+	// Logger, if set, traces each request for debugging pagination and
+	// latency.
+	Logger RequestLogger
 }
 
 // NewClient creates a new Jira API client.
@@ -38,30 +176,125 @@ func NewClient(cfg Config) (*Client, error) {
 	if cfg.URL == "" {
 		return nil, fmt.Errorf("jira URL is required")
 	}
-	if cfg.APIToken == "" {
-		return nil, fmt.Errorf("jira API token is required")
+	if cfg.APIToken == "" && cfg.AccessToken == "" {
+		return nil, fmt.Errorf("jira API token or OAuth access token is required")
 	}
 
-	// Normalize URL
-	baseURL := strings.TrimSuffix(cfg.URL, "/")
+	// Normalize URL, keeping any context path (e.g. https://tools.corp.com/jira)
+	baseURL, err := normalizeBaseURL(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
 	isCloud := strings.Contains(baseURL, "atlassian.net")
 
-	if isCloud && cfg.Username == "" {
+	if isCloud && cfg.AccessToken == "" && cfg.Username == "" {
 		return nil, fmt.Errorf("username (email) is required for Jira Cloud")
 	}
 
+	maxRetries := cfg.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = DefaultMaxRetries
+	} else if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	retryBaseDelay := cfg.RetryBaseDelay
+	if retryBaseDelay <= 0 {
+		retryBaseDelay = DefaultRetryBaseDelay
+	}
+
+	apiVersion := cfg.APIVersion
+	if apiVersion == "" {
+		apiVersion = DefaultAPIVersion
+	}
+	if apiVersion != "2" && apiVersion != "3" {
+		return nil, fmt.Errorf("unsupported jira API version %q: must be \"2\" or \"3\"", cfg.APIVersion)
+	}
+
+	// Parenthesize custom filters so an OR cannot escape the project clause
+	openFilter := DefaultOpenStatusFilter
+	if cfg.OpenStatusFilter != "" {
+		openFilter = "(" + cfg.OpenStatusFilter + ")"
+	}
+	closedFilter := DefaultClosedStatusFilter
+	if cfg.ClosedStatusFilter != "" {
+		closedFilter = "(" + cfg.ClosedStatusFilter + ")"
+	}
+	userAgent := cfg.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	orderBy := cfg.OrderBy
+	if orderBy == "" {
+		orderBy = DefaultOrderBy
+	}
+
+	concurrency := cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	requestTimeout := cfg.RequestTimeout
+	if requestTimeout == 0 {
+		requestTimeout = DefaultRequestTimeout
+	} else if requestTimeout < 0 {
+		requestTimeout = 0
+	}
+
+	// Timeouts are applied per request through the context; see doRequest
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
 	return &Client{
-		baseURL:    baseURL,
-		project:    cfg.Project,
-		username:   cfg.Username,
-		apiToken:   cfg.APIToken,
-		isCloud:    isCloud,
-		httpClient: &http.Client{Timeout: 30 * time.Second},
+		baseURL:        baseURL,
+		project:        cfg.Project,
+		username:       cfg.Username,
+		apiToken:       cfg.APIToken,
+		accessToken:    cfg.AccessToken,
+		isCloud:        isCloud,
+		httpClient:     httpClient,
+		maxRetries:     maxRetries,
+		retryBaseDelay: retryBaseDelay,
+		concurrency:    concurrency,
+		requestTimeout: requestTimeout,
+		location:       cfg.Location,
+		apiVersion:     apiVersion,
+		openFilter:     openFilter,
+		closedFilter:   closedFilter,
+		orderBy:        orderBy,
+		throttle:       newRequestThrottle(cfg.MinRequestInterval),
+		logger:         cfg.Logger,
+		resolveUsers:   cfg.ResolveAccountIDs,
+		progress:       cfg.ProgressFunc,
+		userAgent:      userAgent,
 	}, nil
 }
 
+// apiPath returns the endpoint for path under the configured REST API version,
+// e.g. "/myself" becomes "/rest/api/3/myself".
+func (c *Client) apiPath(path string) string {
+	return "/rest/api/" + c.apiVersion + path
+}
+
+// normalizeBaseURL reduces a configured Jira URL to its scheme, host, and
+// context path, dropping trailing slashes, query, and fragment. Endpoints
+// such as /rest/api/3/... are appended to the result.
+func normalizeBaseURL(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid jira URL %q: expected a full URL such as https://company.atlassian.net", raw)
+	}
+	return u.Scheme + "://" + u.Host + strings.TrimRight(u.Path, "/"), nil
+}
+
 // authHeader returns the appropriate Authorization header value.
 func (c *Client) authHeader() string {
+	if c.accessToken != "" {
+		// OAuth 2.0 access token
+		return "Bearer " + c.accessToken
+	}
 	if c.isCloud || c.username != "" {
 		// Basic auth with username:token (Cloud) or username:password (Server)
 		credentials := c.username + ":" + c.apiToken
@@ -72,56 +305,570 @@ func (c *Client) authHeader() string {
 }
 
 // doRequest executes an HTTP request with authentication.
+// Transient 429 and 503 responses are retried with backoff, honoring the
+// Retry-After header when present. If all attempts fail, the last response
+// is returned so the caller can report it via handleAPIError.
+// Each attempt is bounded by the configured request timeout as well as any
+// deadline on ctx.
 func (c *Client) doRequest(ctx context.Context, method, endpoint string, body io.Reader) (*http.Response, error) {
 	reqURL := c.baseURL + endpoint
 
-	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+	// Buffer the body so it can be replayed on retry
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("reading request body: %w", err)
+		}
 	}
 
-	req.Header.Set("Authorization", c.authHeader())
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "bd-jira/1.0")
+	for attempt := 0; ; attempt++ {
+		if err := c.throttle.wait(ctx); err != nil {
+			return nil, err
+		}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("executing request: %w", err)
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
+
+		reqCtx, cancel := ctx, context.CancelFunc(func() {})
+		if c.requestTimeout > 0 {
+			reqCtx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		}
+
+		req, err := http.NewRequestWithContext(reqCtx, method, reqURL, reqBody)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+
+		req.Header.Set("Authorization", c.authHeader())
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", c.userAgent)
+		// Accept-Encoding is deliberately left unset: the default transport
+		// then requests gzip and decompresses responses transparently,
+		// which setting the header here would turn off.
+
+		start := time.Now()
+		resp, err := c.httpClient.Do(req)
+		if c.logger != nil {
+			status := 0
+			if resp != nil {
+				status = resp.StatusCode
+			}
+			c.logger(method, reqURL, status, time.Since(start))
+		}
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("executing request: %w", err)
+		}
+		// Keep the timeout running until the caller has read the body
+		resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+		if !isRetryableStatus(resp.StatusCode) || attempt >= c.maxRetries {
+			return resp, nil
+		}
+
+		delay := c.retryDelay(resp, attempt)
+
+		// Don't wait past the caller's deadline; surface the last response instead
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return resp, nil
+		}
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// cancelOnClose releases a request's timeout context when its body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// isRetryableStatus reports whether a response status is worth retrying.
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// retryDelay returns how long to wait before the next attempt.
+// Retry-After may be given in seconds or as an HTTP-date, and is capped at
+// MaxRetryAfter; otherwise exponential backoff from the configured base
+// delay is used.
+func (c *Client) retryDelay(resp *http.Response, attempt int) time.Duration {
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			if seconds > int(MaxRetryAfter/time.Second) {
+				return MaxRetryAfter
+			}
+			return time.Duration(seconds) * time.Second
+		}
+		if t, err := http.ParseTime(retryAfter); err == nil {
+			return min(max(time.Until(t), 0), MaxRetryAfter)
+		}
 	}
+	return c.retryBaseDelay * time.Duration(1<<attempt)
+}
+
+// SearchOptions configures SearchIssuesWithOptions.
+type SearchOptions struct {
+	// JQL is an explicit query. If set, State and UpdatedSince are ignored.
+	JQL string
+	// State filters the default project query: "open", "closed", or "all".
+	State string
+	// UpdatedSince limits the default project query to issues updated at or
+	// after this time. The zero value applies no filter.
+	UpdatedSince time.Time
+
+	// Fields limits the fields returned for each issue (e.g. "summary",
+	// "status"), reducing payload size. Empty requests the default set.
+	Fields []string
+	// ExcludeChangelog skips expanding the changelog, which is otherwise
+	// included for status history.
+	ExcludeChangelog bool
+
+	// AllowPartial returns the issues fetched before a mid-search failure,
+	// together with a *PartialResultsError describing where to resume.
+	// By default a failure discards everything fetched so far.
+	AllowPartial bool
+
+	// StartAt and PageToken resume a search from a saved cursor, such as
+	// the StartAt or PageToken of a PartialResultsError: StartAt is the
+	// offset for Server/DC paging and PageToken the nextPageToken for Cloud
+	// token paging. PageToken takes precedence when both are set.
+	StartAt   int
+	PageToken string
 
-	return resp, nil
+	// ReconcileIssues lists issue IDs (e.g. "10001") whose latest updates
+	// Jira Cloud should reflect in the results, for read-after-write
+	// consistency. It is only supported by the Cloud /search/jql endpoint,
+	// so searches with API version 2 fail when it is set.
+	ReconcileIssues []string
+}
+
+// searchQuery is a JQL query together with the parameters sent on every page.
+type searchQuery struct {
+	jql    string
+	params string // Extra query string parameters, each prefixed with "&"
+}
+
+// newSearchQuery builds the per-page parameters for a search from opts.
+func newSearchQuery(jql string, opts SearchOptions) searchQuery {
+	var params strings.Builder
+	if len(opts.Fields) > 0 {
+		params.WriteString("&fields=" + url.QueryEscape(strings.Join(opts.Fields, ",")))
+	}
+	if !opts.ExcludeChangelog {
+		params.WriteString("&expand=changelog")
+	}
+	if len(opts.ReconcileIssues) > 0 {
+		params.WriteString("&reconcileIssues=" + url.QueryEscape(strings.Join(opts.ReconcileIssues, ",")))
+	}
+	return searchQuery{jql: jql, params: params.String()}
 }
 
 // SearchIssues fetches issues from Jira using JQL.
 // If jql is empty, it searches all issues in the configured project.
 // state can be "open", "closed", or "all".
 func (c *Client) SearchIssues(ctx context.Context, jql string, state string) ([]*JiraIssue, error) {
-	// Build JQL query
-	query := jql
-	if query == "" {
-		if c.project == "" {
-			return nil, fmt.Errorf("either project or JQL query is required")
-		}
-		query = fmt.Sprintf("project = %s", c.project)
-		switch state {
-		case "open":
-			query += " AND status != Done AND status != Closed"
-		case "closed":
-			query += " AND (status = Done OR status = Closed)"
+	return c.SearchIssuesWithOptions(ctx, SearchOptions{JQL: jql, State: state})
+}
+
+// SearchIssuesWithOptions fetches issues from Jira using the given options.
+func (c *Client) SearchIssuesWithOptions(ctx context.Context, opts SearchOptions) ([]*JiraIssue, error) {
+	if len(opts.ReconcileIssues) > 0 && c.apiVersion == "2" {
+		return nil, fmt.Errorf("reconcileIssues is not supported by API version 2 search; use version 3")
+	}
+	jql, err := c.buildJQL(opts)
+	if err != nil {
+		return nil, err
+	}
+	query := newSearchQuery(jql, opts)
+
+	var allIssues []*JiraIssue
+	startAt := opts.StartAt
+	pageToken := opts.PageToken
+	tokenPaging := pageToken != ""
+	fannedOut := false
+
+	fail := func(err error) ([]*JiraIssue, error) {
+		if !opts.AllowPartial {
+			return nil, err
+		}
+		return allIssues, &PartialResultsError{Issues: allIssues, StartAt: startAt, PageToken: pageToken, Err: err}
+	}
+
+	for {
+		// Stop promptly rather than waiting for the next request to notice
+		if err := ctx.Err(); err != nil {
+			return fail(err)
+		}
+
+		result, err := c.searchPage(ctx, query, startAt, pageToken)
+		if err != nil {
+			return fail(err)
+		}
+
+		allIssues = append(allIssues, result.Issues...)
+		c.reportProgress(len(allIssues), result.Total)
+
+		// Cloud pages with nextPageToken; once seen, follow tokens until
+		// a page comes back without one. Server/DC pages by startAt.
+		if result.NextPageToken != "" {
+			tokenPaging = true
+			pageToken = result.NextPageToken
+			continue
+		}
+		if tokenPaging {
+			break
+		}
+
+		startAt += len(result.Issues)
+		if startAt >= result.Total || len(result.Issues) == 0 {
+			break
+		}
+
+		if fannedOut || c.concurrency <= 1 {
+			continue
+		}
+		fannedOut = true
+
+		// The first page tells us Total, so fetch the rest in parallel.
+		pages, err := c.searchPagesConcurrently(ctx, query, startAt, len(result.Issues), result.Total)
+		if err != nil {
+			return fail(err)
+		}
+
+		done := false
+		for _, page := range pages {
+			// Results shifted between requests; continue sequentially from here
+			if page.StartAt != startAt {
+				break
+			}
+			allIssues = append(allIssues, page.Issues...)
+			c.reportProgress(len(allIssues), page.Total)
+			startAt += len(page.Issues)
+			if startAt >= page.Total || len(page.Issues) == 0 {
+				done = true
+				break
+			}
+		}
+		if done {
+			break
+		}
+	}
+
+	if c.resolveUsers {
+		if err := c.resolveAccountNames(ctx, allIssues); err != nil {
+			return nil, err
+		}
+	}
+
+	return allIssues, nil
+}
+
+// SearchByAssignee fetches issues assigned to the given user, combined with
+// the project and state filters. On Jira Cloud the user must be given by
+// accountId; Server/DC identifies users by username.
+func (c *Client) SearchByAssignee(ctx context.Context, accountIDOrName, state string) ([]*JiraIssue, error) {
+	if accountIDOrName == "" {
+		return nil, fmt.Errorf("assignee is required")
+	}
+
+	var clauses []string
+	if c.project != "" {
+		clauses = append(clauses, "project = "+quoteJQLValue(c.project))
+	}
+	clauses = append(clauses, "assignee = "+quoteJQLValue(accountIDOrName))
+	switch state {
+	case "open":
+		clauses = append(clauses, c.openFilter)
+	case "closed":
+		clauses = append(clauses, c.closedFilter)
+	}
+
+	return c.SearchIssues(ctx, strings.Join(clauses, " AND ")+" ORDER BY "+c.orderBy, state)
+}
+
+// IssueRef identifies an issue and when it last changed, for cheaply
+// detecting which issues need a full fetch.
+type IssueRef struct {
+	Key     string
+	Updated time.Time
+}
+
+// ListIssueKeys returns the key and updated time of every issue matching
+// jql, requesting only the updated field. If jql is empty, all issues in the
+// configured project are listed.
+func (c *Client) ListIssueKeys(ctx context.Context, jql string) ([]IssueRef, error) {
+	issues, err := c.SearchIssuesWithOptions(ctx, SearchOptions{
+		JQL:              jql,
+		Fields:           []string{"updated"},
+		ExcludeChangelog: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]IssueRef, 0, len(issues))
+	for _, issue := range issues {
+		updated, err := parseJiraTimestamp(issue.Fields.Updated)
+		if err != nil {
+			return nil, fmt.Errorf("parsing updated time of %s: %w", issue.Key, err)
+		}
+		refs = append(refs, IssueRef{Key: issue.Key, Updated: updated})
+	}
+	return refs, nil
+}
+
+// reportProgress passes search progress to the configured ProgressFunc.
+func (c *Client) reportProgress(fetched, total int) {
+	if c.progress == nil {
+		return
+	}
+	if total != 0 && total < fetched {
+		total = fetched
+	}
+	c.progress(fetched, total)
+}
+
+// GetIssuesByKeys fetches the issues with the given keys, batching them into
+// "key in (...)" searches of at most keyBatchSize keys each. Results are
+// returned batch by batch in the order Jira reports them. Keys that do not
+// exist or are not visible are omitted by Jira.
+func (c *Client) GetIssuesByKeys(ctx context.Context, keys []string) ([]*JiraIssue, error) {
+	allIssues := []*JiraIssue{}
+
+	for start := 0; start < len(keys); start += keyBatchSize {
+		end := min(start+keyBatchSize, len(keys))
+		jql := fmt.Sprintf("key in (%s)", quoteJQLList(keys[start:end]))
+
+		issues, err := c.SearchIssuesWithOptions(ctx, SearchOptions{JQL: jql})
+		if err != nil {
+			return nil, fmt.Errorf("fetching keys %d-%d: %w", start+1, end, err)
+		}
+		allIssues = append(allIssues, issues...)
+	}
+
+	return allIssues, nil
+}
+
+// FetchWithSubtasks searches like SearchIssues, then fetches the subtasks
+// (children, via "parent in (...)" in batches of keyBatchSize) of every
+// matched issue, even those the JQL or state would exclude. Subtasks are
+// appended after the matched issues; issues already present are not
+// repeated.
+func (c *Client) FetchWithSubtasks(ctx context.Context, jql, state string) ([]*JiraIssue, error) {
+	issues, err := c.SearchIssues(ctx, jql, state)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(issues))
+	parents := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		if !seen[issue.Key] {
+			seen[issue.Key] = true
+			parents = append(parents, issue.Key)
+		}
+	}
+
+	for start := 0; start < len(parents); start += keyBatchSize {
+		end := min(start+keyBatchSize, len(parents))
+		subtaskJQL := fmt.Sprintf("parent in (%s)", quoteJQLList(parents[start:end]))
+
+		subtasks, err := c.SearchIssuesWithOptions(ctx, SearchOptions{JQL: subtaskJQL})
+		if err != nil {
+			return nil, fmt.Errorf("fetching subtasks of issues %d-%d: %w", start+1, end, err)
+		}
+		for _, subtask := range subtasks {
+			if !seen[subtask.Key] {
+				seen[subtask.Key] = true
+				issues = append(issues, subtask)
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// quoteJQLList quotes each value and joins them for a JQL "in (...)" list.
+func quoteJQLList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = quoteJQLValue(v)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// buildJQL returns the explicit JQL from opts, or builds the default project
+// query with the state and updated-since filters and the configured ordering
+// applied.
+func (c *Client) buildJQL(opts SearchOptions) (string, error) {
+	if opts.JQL != "" {
+		return opts.JQL, nil
+	}
+	if c.project == "" {
+		return "", fmt.Errorf("either project or JQL query is required")
+	}
+
+	query := "project = " + quoteJQLValue(c.project)
+	switch opts.State {
+	case "open":
+		query += " AND " + c.openFilter
+	case "closed":
+		query += " AND " + c.closedFilter
 		// "all" or empty - no additional filter
+	}
+
+	if !opts.UpdatedSince.IsZero() {
+		query += " AND updated >= " + quoteJQLValue(formatJQLTime(opts.UpdatedSince, c.location))
+	}
+
+	return query + " ORDER BY " + c.orderBy, nil
+}
+
+// quoteJQLValue quotes a value for use in JQL, escaping embedded backslashes
+// and double quotes. Quoting keeps values containing spaces or special
+// characters, or matching reserved words (e.g. "AND"), from breaking the query.
+func quoteJQLValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + value + `"`
+}
+
+// formatJQLTime formats a time in the "yyyy-MM-dd HH:mm" form JQL expects.
+// JQL dates are interpreted in the Jira user's time zone, so the time is
+// converted to loc (the user's profile zone, UTC if nil) first.
+func formatJQLTime(t time.Time, loc *time.Location) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return t.In(loc).Format("2006-01-02 15:04")
+}
+
+// searchPage fetches a single page of search results. If pageToken is set,
+// the page is requested by token; otherwise it starts at startAt.
+func (c *Client) searchPage(ctx context.Context, query searchQuery, startAt int, pageToken string) (*searchResponse, error) {
+	// Cloud only serves search at v3 /search/jql (v2 returns HTTP 410 Gone)
+	// See: https://developer.atlassian.com/changelog/#CHANGE-2046
+	// Older Server/DC versions only have v2 /search.
+	searchPath := "/search/jql"
+	if c.apiVersion == "2" {
+		searchPath = "/search"
+	}
+	position := fmt.Sprintf("startAt=%d", startAt)
+	if pageToken != "" {
+		position = "nextPageToken=" + url.QueryEscape(pageToken)
+	}
+	endpoint := c.apiPath(fmt.Sprintf("%s?jql=%s&%s&maxResults=%d%s",
+		searchPath, url.QueryEscape(query.jql), position, searchPageSize, query.params))
+
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, c.handleAPIError(resp.StatusCode, body)
+	}
+
+	var result searchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// searchPagesConcurrently fetches the pages from startAt up to total using a
+// bounded worker pool. Pages are returned in offset order. The first error
+// cancels the remaining requests.
+func (c *Client) searchPagesConcurrently(ctx context.Context, query searchQuery, startAt, pageSize, total int) ([]*searchResponse, error) {
+	var offsets []int
+	for offset := startAt; offset < total; offset += pageSize {
+		offsets = append(offsets, offset)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([]*searchResponse, len(offsets))
+	jobs := make(chan int)
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+
+	workers := min(c.concurrency, len(offsets))
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				page, err := c.searchPage(ctx, query, offsets[i], "")
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+				pages[i] = page
+			}
+		}()
+	}
+
+dispatch:
+	for i := range offsets {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
 		}
 	}
+	close(jobs)
+	wg.Wait()
 
-	var allIssues []*JiraIssue
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return pages, nil
+}
+
+// GetComments fetches all comments on an issue, following pagination.
+func (c *Client) GetComments(ctx context.Context, issueKey string) ([]*JiraComment, error) {
+	var allComments []*JiraComment
 	startAt := 0
 	maxResults := 100
 
 	for {
-		// Use API v3 (v2 returns HTTP 410 Gone)
-		// See: https://developer.atlassian.com/changelog/#CHANGE-2046
-		endpoint := fmt.Sprintf("/rest/api/3/search/jql?jql=%s&startAt=%d&maxResults=%d&expand=changelog",
-			url.QueryEscape(query), startAt, maxResults)
+		endpoint := c.apiPath(fmt.Sprintf("/issue/%s/comment?startAt=%d&maxResults=%d",
+			url.PathEscape(issueKey), startAt, maxResults))
 
 		resp, err := c.doRequest(ctx, "GET", endpoint, nil)
 		if err != nil {
@@ -134,78 +881,273 @@ func (c *Client) SearchIssues(ctx context.Context, jql string, state string) ([]
 			return nil, c.handleAPIError(resp.StatusCode, body)
 		}
 
-		var result searchResponse
+		var result commentsResponse
 		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 			resp.Body.Close()
 			return nil, fmt.Errorf("decoding response: %w", err)
 		}
 		resp.Body.Close()
 
-		allIssues = append(allIssues, result.Issues...)
+		allComments = append(allComments, result.Comments...)
 
-		startAt += len(result.Issues)
-		if startAt >= result.Total || len(result.Issues) == 0 {
+		startAt += len(result.Comments)
+		if startAt >= result.Total || len(result.Comments) == 0 {
 			break
 		}
 	}
 
-	return allIssues, nil
+	return allComments, nil
 }
 
-// handleAPIError creates descriptive error messages for API errors.
-func (c *Client) handleAPIError(statusCode int, body []byte) error {
-	msg := fmt.Sprintf("Jira API error %d", statusCode)
+// GetIssue fetches a single issue by key, including its changelog.
+// Returns an error matching ErrNotFound if the issue does not exist or the
+// credentials cannot see it.
+func (c *Client) GetIssue(ctx context.Context, key string) (*JiraIssue, error) {
+	endpoint := c.apiPath(fmt.Sprintf("/issue/%s?expand=changelog", url.PathEscape(key)))
+
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, c.handleAPIError(resp.StatusCode, body)
+	}
 
-	switch statusCode {
-	case http.StatusUnauthorized:
-		msg += "\nAuthentication failed. Check your credentials."
-		if c.isCloud {
-			msg += "\nFor Jira Cloud, use your email as username and an API token."
-			msg += "\nCreate a token at: https://id.atlassian.com/manage-profile/security/api-tokens"
-		} else {
-			msg += "\nFor Jira Server/DC, use a Personal Access Token or username/password."
+	var issue JiraIssue
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	if c.resolveUsers {
+		if err := c.resolveAccountNames(ctx, []*JiraIssue{&issue}); err != nil {
+			return nil, err
 		}
-	case http.StatusForbidden:
-		msg += fmt.Sprintf("\nAccess forbidden. Check permissions for project.\n%s", string(body))
-	case http.StatusBadRequest:
-		msg += fmt.Sprintf("\nBad request (invalid JQL?): %s", string(body))
-	default:
-		msg += fmt.Sprintf("\n%s", string(body))
 	}
+	return &issue, nil
+}
+
+// Ping checks that the client can reach Jira and that its credentials are
+// accepted. It is cheaper than a search. Use CurrentUser to also learn which
+// account the credentials belong to.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.CurrentUser(ctx)
+	return err
+}
+
+// CurrentUser returns the account the client is authenticated as.
+func (c *Client) CurrentUser(ctx context.Context) (*JiraUser, error) {
+	resp, err := c.doRequest(ctx, "GET", c.apiPath("/myself"), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, c.handleAPIError(resp.StatusCode, body)
+	}
+
+	var user JiraUser
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return &user, nil
+}
+
+// JQLError describes why a JQL query failed validation.
+type JQLError struct {
+	Query    string   // The query that was validated
+	Messages []string // All validation messages reported by Jira
+}
 
-	return fmt.Errorf("%s", msg)
+func (e *JQLError) Error() string {
+	if len(e.Messages) == 0 {
+		return "invalid JQL"
+	}
+	return "invalid JQL: " + e.Messages[0]
+}
+
+// ValidateJQL checks a JQL query using Jira's parse endpoint without running
+// a search. Returns nil if the query is valid, or a *JQLError describing the
+// first problem Jira reported.
+func (c *Client) ValidateJQL(ctx context.Context, jql string) error {
+	reqBody, err := json.Marshal(map[string]any{"queries": []string{jql}})
+	if err != nil {
+		return fmt.Errorf("encoding request: %w", err)
+	}
+
+	resp, err := c.doRequest(ctx, "POST", c.apiPath("/jql/parse?validation=strict"), bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return c.handleAPIError(resp.StatusCode, body)
+	}
+
+	var result jqlParseResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+
+	for _, q := range result.Queries {
+		if len(q.Errors) > 0 {
+			return &JQLError{Query: jql, Messages: q.Errors}
+		}
+	}
+
+	return nil
 }
 
 // searchResponse represents the Jira search API response.
 type searchResponse struct {
-	StartAt    int           `json:"startAt"`
-	MaxResults int           `json:"maxResults"`
-	Total      int           `json:"total"`
-	Issues     []*JiraIssue  `json:"issues"`
+	StartAt       int          `json:"startAt"`
+	MaxResults    int          `json:"maxResults"`
+	Total         int          `json:"total"`
+	Issues        []*JiraIssue `json:"issues"`
+	NextPageToken string       `json:"nextPageToken"` // Cloud token paging; empty on the last page
+}
+
+// jqlParseResponse represents the Jira JQL parse API response.
+type jqlParseResponse struct {
+	Queries []struct {
+		Query  string   `json:"query"`
+		Errors []string `json:"errors"`
+	} `json:"queries"`
+}
+
+// commentsResponse represents the Jira issue comments API response.
+type commentsResponse struct {
+	StartAt    int            `json:"startAt"`
+	MaxResults int            `json:"maxResults"`
+	Total      int            `json:"total"`
+	Comments   []*JiraComment `json:"comments"`
 }
 
 // JiraIssue represents a Jira issue from the API.
 type JiraIssue struct {
-	Key    string          `json:"key"`
-	Fields JiraIssueFields `json:"fields"`
+	Key       string          `json:"key"`
+	Fields    JiraIssueFields `json:"fields"`
+	Changelog *JiraChangelog  `json:"changelog,omitempty"` // Present when searched with expand=changelog
 }
 
 // JiraIssueFields contains the issue field data.
 type JiraIssueFields struct {
-	Summary     string         `json:"summary"`
-	Description any            `json:"description"` // Can be string or ADF document
-	Status      *JiraStatus    `json:"status"`
-	Priority    *JiraPriority  `json:"priority"`
-	IssueType   *JiraIssueType `json:"issuetype"`
-	Assignee    *JiraUser      `json:"assignee"`
-	Reporter    *JiraUser      `json:"reporter"`
-	Labels      []string       `json:"labels"`
-	Created     string         `json:"created"`
-	Updated     string         `json:"updated"`
-	Resolution  *JiraResolution `json:"resolution"`
-	ResolutionDate string      `json:"resolutiondate"`
-	Parent      *JiraParent    `json:"parent"`
-	IssueLinks  []*JiraIssueLink `json:"issuelinks"`
+	Summary        string             `json:"summary"`
+	Description    any                `json:"description"` // Can be string or ADF document
+	Status         *JiraStatus        `json:"status"`
+	Priority       *JiraPriority      `json:"priority"`
+	IssueType      *JiraIssueType     `json:"issuetype"`
+	Assignee       *JiraUser          `json:"assignee"`
+	Reporter       *JiraUser          `json:"reporter"`
+	Labels         []string           `json:"labels"`
+	Created        string             `json:"created"`
+	Updated        string             `json:"updated"`
+	Resolution     *JiraResolution    `json:"resolution"`
+	ResolutionDate string             `json:"resolutiondate"`
+	DueDate        string             `json:"duedate"` // yyyy-MM-dd
+	Parent         *JiraParent        `json:"parent"`
+	IssueLinks     []*JiraIssueLink   `json:"issuelinks"`
+	Sprint         *JiraSprint        `json:"sprint"` // Only set by the Agile API
+	Attachments    []*JiraAttachment  `json:"attachment"`
+	Components     []*JiraComponent   `json:"components"`
+	FixVersions    []*JiraVersion     `json:"fixVersions"`
+	Versions       []*JiraVersion     `json:"versions"` // Affects versions
+	Security       *JiraSecurityLevel `json:"security"`
+	Environment    any                `json:"environment"` // Can be string or ADF document
+	TimeTracking   *JiraTimeTracking  `json:"timetracking"`
+	Votes          *JiraVotes         `json:"votes"`
+
+	// Raw holds every field from the API response keyed by field ID,
+	// including custom fields (customfield_*) that have no typed counterpart.
+	Raw map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the typed fields and also retains the raw field map.
+func (f *JiraIssueFields) UnmarshalJSON(data []byte) error {
+	type fieldsAlias JiraIssueFields // Avoids recursing into this method
+	var typed fieldsAlias
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*f = JiraIssueFields(typed)
+	f.Raw = raw
+	return nil
+}
+
+// MarshalJSON encodes the fields so that a decode/encode round trip leaves the
+// payload unchanged. Fields present in Raw are emitted as their raw originals
+// unless the typed value was modified since decoding, in which case the typed
+// value is merged over the raw object so unmodeled keys (e.g. a status's
+// statusCategory) survive. Typed fields absent from Raw and unmodified are
+// omitted rather than encoded as null.
+func (f JiraIssueFields) MarshalJSON() ([]byte, error) {
+	type fieldsAlias JiraIssueFields
+	typed, err := json.Marshal(fieldsAlias(f))
+	if err != nil || len(f.Raw) == 0 {
+		return typed, err
+	}
+
+	// Encode what the typed fields looked like right after decoding Raw,
+	// to tell which of them the caller has since changed.
+	rawData, err := json.Marshal(f.Raw)
+	if err != nil {
+		return nil, err
+	}
+	var decoded fieldsAlias
+	if err := json.Unmarshal(rawData, &decoded); err != nil {
+		return nil, err
+	}
+	original, err := json.Marshal(decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var typedFields, originalFields map[string]json.RawMessage
+	if err := json.Unmarshal(typed, &typedFields); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(original, &originalFields); err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]json.RawMessage, len(f.Raw)+len(typedFields))
+	for k, v := range f.Raw {
+		merged[k] = v
+	}
+	for k, v := range typedFields {
+		if bytes.Equal(v, originalFields[k]) {
+			continue
+		}
+		merged[k] = mergeRawObject(f.Raw[k], v)
+	}
+	return json.Marshal(merged)
+}
+
+// mergeRawObject overlays the keys of a typed JSON object onto its raw
+// original. Anything other than two objects yields the typed value.
+func mergeRawObject(raw, typed json.RawMessage) json.RawMessage {
+	var rawObj, typedObj map[string]json.RawMessage
+	if json.Unmarshal(raw, &rawObj) != nil || json.Unmarshal(typed, &typedObj) != nil ||
+		rawObj == nil || typedObj == nil {
+		return typed
+	}
+	for k, v := range typedObj {
+		rawObj[k] = v
+	}
+	merged, err := json.Marshal(rawObj)
+	if err != nil {
+		return typed
+	}
+	return merged
 }
 
 // JiraStatus represents a Jira status.
@@ -215,22 +1157,78 @@ type JiraStatus struct {
 
 // JiraPriority represents a Jira priority.
 type JiraPriority struct {
+	ID   string `json:"id,omitempty"` // Stable across languages, unlike Name
 	Name string `json:"name"`
 }
 
 // JiraIssueType represents a Jira issue type.
 type JiraIssueType struct {
-	Name    string `json:"name"`
-	Subtask bool   `json:"subtask"`
+	Name           string `json:"name"`
+	Subtask        bool   `json:"subtask"`
+	HierarchyLevel int    `json:"hierarchyLevel"` // Cloud: 1 for epics, 0 for standard types, -1 for subtasks
 }
 
 // JiraUser represents a Jira user.
 type JiraUser struct {
-	Name        string `json:"name"`        // Server/DC
-	DisplayName string `json:"displayName"` // Cloud
+	AccountID    string `json:"accountId"`   // Cloud stable user identifier
+	Name         string `json:"name"`        // Server/DC
+	DisplayName  string `json:"displayName"` // Cloud
 	EmailAddress string `json:"emailAddress"`
 }
 
+// JiraComment represents a comment on a Jira issue.
+type JiraComment struct {
+	ID      string    `json:"id"`
+	Author  *JiraUser `json:"author"`
+	Body    any       `json:"body"` // Can be string or ADF document
+	Created string    `json:"created"`
+	Updated string    `json:"updated"`
+}
+
+// GetBody returns the comment body as a plain string.
+// Handles both string bodies (Server/DC) and ADF documents (Cloud).
+func (c *JiraComment) GetBody() string {
+	return extractText(c.Body)
+}
+
+// JiraComponent represents a Jira project component.
+type JiraComponent struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// JiraVersion represents a Jira project version (release).
+type JiraVersion struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Released    bool   `json:"released"`
+	ReleaseDate string `json:"releaseDate"` // yyyy-MM-dd, empty if unscheduled
+}
+
+// JiraVotes holds an issue's vote count and whether the caller has voted.
+type JiraVotes struct {
+	Votes    int  `json:"votes"`
+	HasVoted bool `json:"hasVoted"`
+}
+
+// JiraTimeTracking holds an issue's time tracking totals. The *Seconds fields
+// are authoritative; the string forms (e.g. "1w 2d") are for display.
+type JiraTimeTracking struct {
+	OriginalEstimate         string `json:"originalEstimate"`
+	RemainingEstimate        string `json:"remainingEstimate"`
+	TimeSpent                string `json:"timeSpent"`
+	OriginalEstimateSeconds  int64  `json:"originalEstimateSeconds"`
+	RemainingEstimateSeconds int64  `json:"remainingEstimateSeconds"`
+	TimeSpentSeconds         int64  `json:"timeSpentSeconds"`
+}
+
+// JiraSecurityLevel represents the security level restricting an issue's visibility.
+type JiraSecurityLevel struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
 // JiraResolution represents a Jira resolution.
 type JiraResolution struct {
 	Name string `json:"name"`
@@ -238,12 +1236,19 @@ type JiraResolution struct {
 
 // JiraParent represents a parent issue reference.
 type JiraParent struct {
-	Key string `json:"key"`
+	Key    string           `json:"key"`
+	Fields JiraParentFields `json:"fields"`
+}
+
+// JiraParentFields holds the summary fields Jira includes for a parent.
+type JiraParentFields struct {
+	Summary   string         `json:"summary"`
+	IssueType *JiraIssueType `json:"issuetype"`
 }
 
 // JiraIssueLink represents an issue link.
 type JiraIssueLink struct {
-	Type         *JiraLinkType `json:"type"`
+	Type         *JiraLinkType    `json:"type"`
 	InwardIssue  *JiraLinkedIssue `json:"inwardIssue"`
 	OutwardIssue *JiraLinkedIssue `json:"outwardIssue"`
 }
@@ -263,60 +1268,17 @@ type JiraLinkedIssue struct {
 // GetDescription returns the description as a plain string.
 // Handles both string descriptions (Server/DC) and ADF documents (Cloud).
 func (f *JiraIssueFields) GetDescription() string {
-	if f.Description == nil {
-		return ""
-	}
-
-	// Try string first (Jira Server/DC)
-	if s, ok := f.Description.(string); ok {
-		return s
-	}
-
-	// Try ADF document (Jira Cloud)
-	if doc, ok := f.Description.(map[string]any); ok {
-		return extractTextFromADF(doc)
-	}
-
-	return ""
+	return extractText(f.Description)
 }
 
-// extractTextFromADF extracts plain text from Atlassian Document Format.
-func extractTextFromADF(doc map[string]any) string {
-	var sb strings.Builder
-	extractTextFromNode(doc, &sb)
-	return strings.TrimSpace(sb.String())
+// extractText returns a rich-text field value as a plain string.
+// Jira Server/DC sends plain strings while Cloud sends ADF documents.
+func extractText(value any) string {
+	return adfRenderer{}.extractText(value)
 }
 
-func extractTextFromNode(node map[string]any, sb *strings.Builder) {
-	// Handle text nodes
-	if nodeType, ok := node["type"].(string); ok {
-		if nodeType == "text" {
-			if text, ok := node["text"].(string); ok {
-				sb.WriteString(text)
-			}
-			return
-		}
-
-		// Add newlines for block elements
-		if nodeType == "paragraph" || nodeType == "heading" || nodeType == "bulletList" ||
-			nodeType == "orderedList" || nodeType == "listItem" || nodeType == "codeBlock" {
-			if sb.Len() > 0 && !strings.HasSuffix(sb.String(), "\n") {
-				sb.WriteString("\n")
-			}
-		}
-	}
-
-	// Recurse into content array
-	if content, ok := node["content"].([]any); ok {
-		for _, child := range content {
-			if childNode, ok := child.(map[string]any); ok {
-				extractTextFromNode(childNode, sb)
-			}
-		}
-	}
-}
-
-// GetDisplayName returns the best available name for a user.
+// GetDisplayName returns the best available name for a user, falling back
+// to the account ID when GDPR-strict Cloud sites omit the name and email.
 func (u *JiraUser) GetDisplayName() string {
 	if u == nil {
 		return ""
@@ -327,18 +1289,43 @@ func (u *JiraUser) GetDisplayName() string {
 	if u.Name != "" {
 		return u.Name
 	}
-	return u.EmailAddress
+	if u.EmailAddress != "" {
+		return u.EmailAddress
+	}
+	return u.AccountID
 }
 
-// ExtractKeyFromURL extracts a Jira issue key from a browse URL.
+// GetID returns the user's stable identifier for writes: the accountId on
+// Cloud, or the username on Server/DC, which has no accountId.
+func (u *JiraUser) GetID() string {
+	if u == nil {
+		return ""
+	}
+	if u.AccountID != "" {
+		return u.AccountID
+	}
+	return u.Name
+}
+
+// issueURLPatterns match the URL shapes Jira uses to link to an issue,
+// tried in order. Each captures the issue key as its first group:
+//
+//	https://company.atlassian.net/browse/PROJ-123
+//	https://jira.company.com/projects/PROJ/issues/PROJ-123
+//	https://jira.company.com/jira/software/c/projects/PROJ/boards/1?selectedIssue=PROJ-123
+var issueURLPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`/browse/([A-Z]+-\d+)`),
+	regexp.MustCompile(`/projects/[A-Z]+/issues/([A-Z]+-\d+)`),
+	regexp.MustCompile(`[?&]selectedIssue=([A-Z]+-\d+)`),
+}
+
+// ExtractKeyFromURL extracts a Jira issue key from an issue URL.
 // Returns empty string if no key is found.
 func ExtractKeyFromURL(externalRef string) string {
-	// Match patterns like:
-	// https://company.atlassian.net/browse/PROJ-123
-	// https://jira.company.com/browse/PROJ-123
-	re := regexp.MustCompile(`/browse/([A-Z]+-\d+)`)
-	if matches := re.FindStringSubmatch(externalRef); len(matches) == 2 {
-		return matches[1]
+	for _, re := range issueURLPatterns {
+		if matches := re.FindStringSubmatch(externalRef); len(matches) == 2 {
+			return matches[1]
+		}
 	}
 	return ""
 }
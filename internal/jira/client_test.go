@@ -0,0 +1,1477 @@
+package jira
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// roundTripFunc adapts a function into an http.RoundTripper for mocking.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// jsonResponse builds a mock HTTP response with a JSON body.
+func jsonResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// newMockClient creates a client whose requests are served by fn.
+func newMockClient(t *testing.T, fn roundTripFunc) *Client {
+	t.Helper()
+	return newTestClientWithHTTP(t, "https://jira.example.com", &http.Client{Transport: fn})
+}
+
+// newTestClient creates a client pointed at a test server.
+func newTestClient(t *testing.T, serverURL string) *Client {
+	t.Helper()
+	return newTestClientWithHTTP(t, serverURL, nil)
+}
+
+// newTestClientWithHTTP creates a test client using the given HTTP client.
+func newTestClientWithHTTP(t *testing.T, serverURL string, httpClient *http.Client) *Client {
+	t.Helper()
+	client, err := NewClient(Config{
+		URL:            serverURL,
+		Project:        "PROJ",
+		Username:       "user",
+		APIToken:       "token",
+		RetryBaseDelay: time.Millisecond,
+		HTTPClient:     httpClient,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestNewClient_RetryDefaults(t *testing.T) {
+	client, err := NewClient(Config{URL: "https://jira.example.com", APIToken: "token"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.maxRetries != DefaultMaxRetries {
+		t.Errorf("maxRetries = %d, want %d", client.maxRetries, DefaultMaxRetries)
+	}
+	if client.retryBaseDelay != DefaultRetryBaseDelay {
+		t.Errorf("retryBaseDelay = %v, want %v", client.retryBaseDelay, DefaultRetryBaseDelay)
+	}
+
+	client, err = NewClient(Config{URL: "https://jira.example.com", APIToken: "token", MaxRetries: -1})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.maxRetries != 0 {
+		t.Errorf("maxRetries = %d, want 0 when disabled", client.maxRetries)
+	}
+}
+
+func TestDoRequest_RetriesRateLimited(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch attempts {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	resp, err := client.doRequest(context.Background(), "GET", "/rest/api/3/myself", nil)
+	if err != nil {
+		t.Fatalf("doRequest() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestSearchIssues_GzipResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept-Encoding"); got != "gzip" {
+			t.Errorf("Accept-Encoding = %q, want gzip added by the transport", got)
+		}
+		var compressed bytes.Buffer
+		zw := gzip.NewWriter(&compressed)
+		_, _ = io.WriteString(zw, searchPageJSON(0, 3, 3))
+		_ = zw.Close()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(compressed.Bytes())
+	}))
+	defer server.Close()
+
+	issues, err := newTestClient(t, server.URL).SearchIssues(context.Background(), "", "all")
+	if err != nil {
+		t.Fatalf("SearchIssues() error = %v", err)
+	}
+	assertSequentialKeys(t, issues, 3)
+}
+
+func TestDoRequest_LeavesAcceptEncodingToTransport(t *testing.T) {
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		// Setting it here would disable the transport's transparent gzip
+		if got := req.Header.Get("Accept-Encoding"); got != "" {
+			t.Errorf("Accept-Encoding = %q, want it unset", got)
+		}
+		return jsonResponse(http.StatusOK, `{}`), nil
+	})
+
+	resp, err := client.doRequest(context.Background(), "GET", "/rest/api/3/myself", nil)
+	if err != nil {
+		t.Fatalf("doRequest() error = %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestDoRequest_RetriesExhausted(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	resp, err := client.doRequest(context.Background(), "GET", "/rest/api/3/myself", nil)
+	if err != nil {
+		t.Fatalf("doRequest() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusTooManyRequests)
+	}
+	if want := DefaultMaxRetries + 1; attempts != want {
+		t.Errorf("attempts = %d, want %d", attempts, want)
+	}
+}
+
+func TestDoRequest_Logger(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	type logEntry struct {
+		method, url string
+		status      int
+	}
+	var entries []logEntry
+	client, err := NewClient(Config{
+		URL:      server.URL,
+		Username: "user",
+		APIToken: "secret-token",
+		Logger: func(method, url string, status int, dur time.Duration) {
+			if dur < 0 {
+				t.Errorf("dur = %v, want >= 0", dur)
+			}
+			entries = append(entries, logEntry{method, url, status})
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	resp, err := client.doRequest(context.Background(), "POST", "/rest/api/3/issue", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("doRequest() error = %v", err)
+	}
+	resp.Body.Close()
+
+	want := []logEntry{
+		{"POST", server.URL + "/rest/api/3/issue", http.StatusTooManyRequests},
+		{"POST", server.URL + "/rest/api/3/issue", http.StatusOK},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("logged %+v, want %+v", entries, want)
+	}
+	for _, e := range entries {
+		if strings.Contains(e.url, "secret-token") {
+			t.Errorf("logged URL %q contains credentials", e.url)
+		}
+	}
+}
+
+func TestDoRequest_UserAgent(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		want      string
+	}{
+		{"default", "", DefaultUserAgent},
+		{"custom", "beads-sync/2.3", "beads-sync/2.3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			client, err := NewClient(Config{
+				URL:       "https://jira.example.com",
+				APIToken:  "token",
+				UserAgent: tt.userAgent,
+				HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					got = req.Header.Get("User-Agent")
+					return jsonResponse(http.StatusOK, `{}`), nil
+				})},
+			})
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			if err := client.Ping(context.Background()); err != nil {
+				t.Fatalf("Ping() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("User-Agent = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoRequest_RetryRespectsDeadline(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	resp, err := client.doRequest(ctx, "GET", "/rest/api/3/myself", nil)
+	if err != nil {
+		t.Fatalf("doRequest() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (Retry-After exceeds deadline)", attempts)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	client := &Client{retryBaseDelay: 100 * time.Millisecond}
+
+	tests := []struct {
+		name       string
+		retryAfter string
+		attempt    int
+		want       time.Duration
+	}{
+		{"seconds", "5", 0, 5 * time.Second},
+		{"seconds capped", "86400", 0, MaxRetryAfter},
+		{"overflowing seconds capped", "9999999999999", 0, MaxRetryAfter},
+		{"past HTTP-date", "Wed, 21 Oct 2015 07:28:00 GMT", 0, 0},
+		{"distant HTTP-date capped", "Fri, 01 Jan 2100 00:00:00 GMT", 0, MaxRetryAfter},
+		{"backoff first attempt", "", 0, 100 * time.Millisecond},
+		{"backoff third attempt", "", 2, 400 * time.Millisecond},
+		{"invalid header falls back", "soon", 1, 200 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.retryAfter != "" {
+				resp.Header.Set("Retry-After", tt.retryAfter)
+			}
+			if got := client.retryDelay(resp, tt.attempt); got != tt.want {
+				t.Errorf("retryDelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetComments_Paginated(t *testing.T) {
+	pages := map[string]string{
+		"0": `{"startAt": 0, "maxResults": 2, "total": 3, "comments": [
+			{"id": "1", "author": {"displayName": "Alice"}, "body": "First", "created": "2024-01-15T10:30:00.000+0000"},
+			{"id": "2", "author": {"displayName": "Bob"}, "body": {"type": "doc", "content": [
+				{"type": "paragraph", "content": [{"type": "text", "text": "ADF comment"}]}
+			]}, "created": "2024-01-15T11:00:00.000+0000", "updated": "2024-01-15T12:00:00.000+0000"}
+		]}`,
+		"2": `{"startAt": 2, "maxResults": 2, "total": 3, "comments": [
+			{"id": "3", "author": {"displayName": "Carol"}, "body": "Third"}
+		]}`,
+	}
+
+	var requested []string
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/rest/api/3/issue/PROJ-1/comment" {
+			t.Errorf("unexpected path %q", req.URL.Path)
+		}
+		startAt := req.URL.Query().Get("startAt")
+		requested = append(requested, startAt)
+		body, ok := pages[startAt]
+		if !ok {
+			return jsonResponse(http.StatusBadRequest, `{"errorMessages": ["bad startAt"]}`), nil
+		}
+		return jsonResponse(http.StatusOK, body), nil
+	})
+
+	comments, err := client.GetComments(context.Background(), "PROJ-1")
+	if err != nil {
+		t.Fatalf("GetComments() error = %v", err)
+	}
+
+	if len(requested) != 2 {
+		t.Errorf("requests = %v, want 2 pages", requested)
+	}
+	if len(comments) != 3 {
+		t.Fatalf("GetComments() returned %d comments, want 3", len(comments))
+	}
+	if got := comments[0].Author.GetDisplayName(); got != "Alice" {
+		t.Errorf("comments[0].Author = %q, want %q", got, "Alice")
+	}
+	if got := comments[1].GetBody(); got != "ADF comment" {
+		t.Errorf("comments[1].GetBody() = %q, want %q", got, "ADF comment")
+	}
+	if comments[1].Updated != "2024-01-15T12:00:00.000+0000" {
+		t.Errorf("comments[1].Updated = %q", comments[1].Updated)
+	}
+	if got := comments[2].GetBody(); got != "Third" {
+		t.Errorf("comments[2].GetBody() = %q, want %q", got, "Third")
+	}
+}
+
+func TestGetComments_Error(t *testing.T) {
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusForbidden, `{"errorMessages": ["no access"]}`), nil
+	})
+
+	_, err := client.GetComments(context.Background(), "PROJ-1")
+	if err == nil {
+		t.Fatal("GetComments() expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "Jira API error 403") {
+		t.Errorf("error = %q, want Jira API error 403", err.Error())
+	}
+}
+
+// searchPageJSON builds a search response with sequentially keyed issues.
+func searchPageJSON(startAt, count, total int) string {
+	issues := make([]string, count)
+	for i := range issues {
+		issues[i] = fmt.Sprintf(`{"key": "PROJ-%d", "fields": {"summary": "Issue %d"}}`, startAt+i+1, startAt+i+1)
+	}
+	return fmt.Sprintf(`{"startAt": %d, "maxResults": %d, "total": %d, "issues": [%s]}`,
+		startAt, searchPageSize, total, strings.Join(issues, ","))
+}
+
+// assertSequentialKeys checks that issues are PROJ-1..PROJ-n in order.
+func assertSequentialKeys(t *testing.T, issues []*JiraIssue, n int) {
+	t.Helper()
+	if len(issues) != n {
+		t.Fatalf("got %d issues, want %d", len(issues), n)
+	}
+	for i, issue := range issues {
+		if want := fmt.Sprintf("PROJ-%d", i+1); issue.Key != want {
+			t.Fatalf("issues[%d].Key = %q, want %q", i, issue.Key, want)
+		}
+	}
+}
+
+func TestGetIssuesByKeys_Batches(t *testing.T) {
+	keys := make([]string, 250)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("PROJ-%d", i+1)
+	}
+
+	keyPattern := regexp.MustCompile(`"(PROJ-\d+)"`)
+	var batchSizes []int
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		jql := req.URL.Query().Get("jql")
+		if !strings.HasPrefix(jql, "key in (") {
+			t.Errorf("jql = %q, want key in (...)", jql)
+		}
+		matches := keyPattern.FindAllStringSubmatch(jql, -1)
+		batchSizes = append(batchSizes, len(matches))
+
+		issues := make([]string, len(matches))
+		for i, m := range matches {
+			issues[i] = fmt.Sprintf(`{"key": %q, "fields": {"summary": "Issue"}}`, m[1])
+		}
+		return jsonResponse(http.StatusOK, fmt.Sprintf(`{"startAt": 0, "maxResults": 100, "total": %d, "issues": [%s]}`,
+			len(matches), strings.Join(issues, ","))), nil
+	})
+
+	issues, err := client.GetIssuesByKeys(context.Background(), keys)
+	if err != nil {
+		t.Fatalf("GetIssuesByKeys() error = %v", err)
+	}
+	if !reflect.DeepEqual(batchSizes, []int{100, 100, 50}) {
+		t.Errorf("batch sizes = %v, want [100 100 50]", batchSizes)
+	}
+	assertSequentialKeys(t, issues, 250)
+}
+
+func TestGetIssuesByKeys_Empty(t *testing.T) {
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected request %s", req.URL)
+		return jsonResponse(http.StatusOK, `{}`), nil
+	})
+
+	issues, err := client.GetIssuesByKeys(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("GetIssuesByKeys() error = %v", err)
+	}
+	if issues == nil || len(issues) != 0 {
+		t.Errorf("GetIssuesByKeys(nil) = %v, want empty slice", issues)
+	}
+}
+
+func TestFetchWithSubtasks(t *testing.T) {
+	var queries []string
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		jql := req.URL.Query().Get("jql")
+		queries = append(queries, jql)
+		if strings.HasPrefix(jql, "parent in (") {
+			// PROJ-3 matched the main search too
+			return jsonResponse(http.StatusOK, `{"total": 3, "issues": [
+				{"key": "PROJ-3", "fields": {"parent": {"key": "PROJ-1"}}},
+				{"key": "PROJ-4", "fields": {"parent": {"key": "PROJ-1"}}},
+				{"key": "PROJ-5", "fields": {"parent": {"key": "PROJ-2"}}}
+			]}`), nil
+		}
+		return jsonResponse(http.StatusOK, searchPageJSON(0, 3, 3)), nil
+	})
+
+	issues, err := client.FetchWithSubtasks(context.Background(), "labels = release", "open")
+	if err != nil {
+		t.Fatalf("FetchWithSubtasks() error = %v", err)
+	}
+
+	assertSequentialKeys(t, issues, 5)
+	want := []string{"labels = release", `parent in ("PROJ-1", "PROJ-2", "PROJ-3")`}
+	if !reflect.DeepEqual(queries, want) {
+		t.Errorf("queries = %q, want %q", queries, want)
+	}
+}
+
+func TestSearchIssuesWithOptions_Fields(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          SearchOptions
+		wantFields    string
+		wantChangelog bool
+		wantReconcile string
+	}{
+		{"defaults", SearchOptions{}, "", true, ""},
+		{"fields without changelog", SearchOptions{Fields: []string{"summary", "status"}, ExcludeChangelog: true}, "summary,status", false, ""},
+		{"reconcile issues", SearchOptions{ReconcileIssues: []string{"10001", "10002"}}, "", true, "10001,10002"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query url.Values
+			client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+				query = req.URL.Query()
+				return jsonResponse(http.StatusOK, searchPageJSON(0, 1, 1)), nil
+			})
+
+			if _, err := client.SearchIssuesWithOptions(context.Background(), tt.opts); err != nil {
+				t.Fatalf("SearchIssuesWithOptions() error = %v", err)
+			}
+			if got := query.Get("fields"); got != tt.wantFields {
+				t.Errorf("fields = %q, want %q", got, tt.wantFields)
+			}
+			if got := query.Get("expand") == "changelog"; got != tt.wantChangelog {
+				t.Errorf("expand=changelog present = %v, want %v", got, tt.wantChangelog)
+			}
+			if got := query.Get("reconcileIssues"); got != tt.wantReconcile || query.Has("reconcileIssues") != (tt.wantReconcile != "") {
+				t.Errorf("reconcileIssues = %q (present %v), want %q", got, query.Has("reconcileIssues"), tt.wantReconcile)
+			}
+		})
+	}
+}
+
+func TestSearchIssuesWithOptions_ReconcileRequiresV3(t *testing.T) {
+	client, err := NewClient(Config{
+		URL:        "https://jira.example.com",
+		Project:    "PROJ",
+		Username:   "user",
+		APIToken:   "token",
+		APIVersion: "2",
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected request %s", req.URL)
+			return jsonResponse(http.StatusOK, searchPageJSON(0, 1, 1)), nil
+		})},
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	_, err = client.SearchIssuesWithOptions(context.Background(), SearchOptions{ReconcileIssues: []string{"10001"}})
+	if err == nil || !strings.Contains(err.Error(), "API version 2") {
+		t.Errorf("SearchIssuesWithOptions() error = %v, want v2 unsupported", err)
+	}
+}
+
+func TestListIssueKeys(t *testing.T) {
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		q := req.URL.Query()
+		if q.Get("fields") != "updated" {
+			t.Errorf("fields = %q, want updated", q.Get("fields"))
+		}
+		if q.Has("expand") {
+			t.Errorf("expand = %q, want none", q.Get("expand"))
+		}
+		return jsonResponse(http.StatusOK, `{"startAt": 0, "maxResults": 100, "total": 2, "issues": [
+			{"id": "10001", "key": "PROJ-1", "fields": {"updated": "2024-01-15T10:30:00.000+0000"}},
+			{"id": "10002", "key": "PROJ-2", "fields": {"updated": "2024-01-16T08:00:00.000-0500"}}
+		]}`), nil
+	})
+
+	refs, err := client.ListIssueKeys(context.Background(), "project = PROJ")
+	if err != nil {
+		t.Fatalf("ListIssueKeys() error = %v", err)
+	}
+
+	want := []IssueRef{
+		{Key: "PROJ-1", Updated: time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)},
+		{Key: "PROJ-2", Updated: time.Date(2024, 1, 16, 13, 0, 0, 0, time.UTC)},
+	}
+	if len(refs) != len(want) {
+		t.Fatalf("got %d refs, want %d", len(refs), len(want))
+	}
+	for i := range want {
+		if refs[i].Key != want[i].Key || !refs[i].Updated.Equal(want[i].Updated) {
+			t.Errorf("refs[%d] = %+v, want %+v", i, refs[i], want[i])
+		}
+	}
+}
+
+func TestSearchIssues_TokenPagination(t *testing.T) {
+	pages := map[string]string{
+		"":      `{"issues": [{"key": "PROJ-1"}, {"key": "PROJ-2"}], "nextPageToken": "tok-a"}`,
+		"tok-a": `{"issues": [{"key": "PROJ-3"}, {"key": "PROJ-4"}], "nextPageToken": "tok-b"}`,
+		"tok-b": `{"issues": [{"key": "PROJ-5"}], "isLast": true}`,
+	}
+
+	var tokens []string
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		q := req.URL.Query()
+		token := q.Get("nextPageToken")
+		if token != "" && q.Has("startAt") {
+			t.Errorf("request with token %q also sent startAt", token)
+		}
+		tokens = append(tokens, token)
+		body, ok := pages[token]
+		if !ok {
+			return jsonResponse(http.StatusBadRequest, `{"errorMessages": ["bad token"]}`), nil
+		}
+		return jsonResponse(http.StatusOK, body), nil
+	})
+
+	issues, err := client.SearchIssues(context.Background(), "", "all")
+	if err != nil {
+		t.Fatalf("SearchIssues() error = %v", err)
+	}
+	if !reflect.DeepEqual(tokens, []string{"", "tok-a", "tok-b"}) {
+		t.Errorf("requested tokens = %q, want [\"\" tok-a tok-b]", tokens)
+	}
+	assertSequentialKeys(t, issues, 5)
+}
+
+func TestSearchIssues_ProgressFunc(t *testing.T) {
+	tests := []struct {
+		name  string
+		pages map[string]string
+		want  [][2]int
+	}{
+		{
+			name: "offset paging with total",
+			pages: map[string]string{
+				"0":   searchPageJSON(0, searchPageSize, 250),
+				"100": searchPageJSON(100, searchPageSize, 250),
+				"200": searchPageJSON(200, 50, 250),
+			},
+			want: [][2]int{{100, 250}, {200, 250}, {250, 250}},
+		},
+		{
+			name: "total shrinks mid-run",
+			pages: map[string]string{
+				"0":   searchPageJSON(0, searchPageSize, 300),
+				"100": searchPageJSON(100, searchPageSize, 150),
+			},
+			want: [][2]int{{100, 300}, {200, 200}},
+		},
+		{
+			name: "token paging without total",
+			pages: map[string]string{
+				"0":     `{"issues": [{"key": "PROJ-1"}, {"key": "PROJ-2"}], "nextPageToken": "tok-a"}`,
+				"tok-a": `{"issues": [{"key": "PROJ-3"}], "isLast": true}`,
+			},
+			want: [][2]int{{2, 0}, {3, 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+				q := req.URL.Query()
+				page := q.Get("nextPageToken")
+				if page == "" {
+					page = q.Get("startAt")
+				}
+				return jsonResponse(http.StatusOK, tt.pages[page]), nil
+			})
+			client.concurrency = 1
+
+			var got [][2]int
+			client.progress = func(fetched, total int) {
+				got = append(got, [2]int{fetched, total})
+			}
+
+			if _, err := client.SearchIssues(context.Background(), "", "all"); err != nil {
+				t.Fatalf("SearchIssues() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("progress calls = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchIssuesWithOptions_Resume(t *testing.T) {
+	t.Run("offset", func(t *testing.T) {
+		var offsets []string
+		client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			offsets = append(offsets, q.Get("startAt"))
+			startAt, _ := strconv.Atoi(q.Get("startAt"))
+			return jsonResponse(http.StatusOK, searchPageJSON(startAt, min(searchPageSize, 250-startAt), 250)), nil
+		})
+		client.concurrency = 1
+
+		issues, err := client.SearchIssuesWithOptions(context.Background(), SearchOptions{StartAt: 100})
+		if err != nil {
+			t.Fatalf("SearchIssuesWithOptions() error = %v", err)
+		}
+		if !reflect.DeepEqual(offsets, []string{"100", "200"}) {
+			t.Errorf("requested offsets = %v, want [100 200]", offsets)
+		}
+		if len(issues) != 150 || issues[0].Key != "PROJ-101" {
+			t.Errorf("got %d issues starting at %q, want 150 starting at PROJ-101", len(issues), issues[0].Key)
+		}
+	})
+
+	t.Run("page token", func(t *testing.T) {
+		var requests []url.Values
+		client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.URL.Query())
+			return jsonResponse(http.StatusOK, `{"issues": [{"key": "PROJ-3"}], "isLast": true}`), nil
+		})
+
+		issues, err := client.SearchIssuesWithOptions(context.Background(), SearchOptions{PageToken: "tok-b"})
+		if err != nil {
+			t.Fatalf("SearchIssuesWithOptions() error = %v", err)
+		}
+		if len(requests) != 1 || requests[0].Get("nextPageToken") != "tok-b" || requests[0].Has("startAt") {
+			t.Errorf("requests = %v, want a single request with nextPageToken=tok-b", requests)
+		}
+		if len(issues) != 1 || issues[0].Key != "PROJ-3" {
+			t.Errorf("issues = %v, want [PROJ-3]", issues)
+		}
+	})
+}
+
+func TestSearchIssues_ConcurrentPagination(t *testing.T) {
+	const total = 450
+
+	var mu sync.Mutex
+	requested := map[int]int{}
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		startAt, _ := strconv.Atoi(req.URL.Query().Get("startAt"))
+		mu.Lock()
+		requested[startAt]++
+		mu.Unlock()
+		return jsonResponse(http.StatusOK, searchPageJSON(startAt, min(searchPageSize, total-startAt), total)), nil
+	})
+
+	issues, err := client.SearchIssues(context.Background(), "", "all")
+	if err != nil {
+		t.Fatalf("SearchIssues() error = %v", err)
+	}
+
+	assertSequentialKeys(t, issues, total)
+	for _, offset := range []int{0, 100, 200, 300, 400} {
+		if requested[offset] != 1 {
+			t.Errorf("offset %d requested %d times, want 1", offset, requested[offset])
+		}
+	}
+}
+
+func TestSearchIssues_ShiftedOffsetsFallBackToSequential(t *testing.T) {
+	const total = 300
+
+	var mu sync.Mutex
+	shifted := false
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		startAt, _ := strconv.Atoi(req.URL.Query().Get("startAt"))
+		mu.Lock()
+		defer mu.Unlock()
+		// The first fetch of the middle page reports a different offset,
+		// as if issues were inserted while paginating.
+		if startAt == 100 && !shifted {
+			shifted = true
+			return jsonResponse(http.StatusOK, searchPageJSON(90, searchPageSize, total)), nil
+		}
+		return jsonResponse(http.StatusOK, searchPageJSON(startAt, min(searchPageSize, total-startAt), total)), nil
+	})
+
+	issues, err := client.SearchIssues(context.Background(), "", "all")
+	if err != nil {
+		t.Fatalf("SearchIssues() error = %v", err)
+	}
+
+	assertSequentialKeys(t, issues, total)
+}
+
+func TestSearchIssues_ConcurrentErrorCancels(t *testing.T) {
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		startAt, _ := strconv.Atoi(req.URL.Query().Get("startAt"))
+		if startAt == 200 {
+			return jsonResponse(http.StatusInternalServerError, `{"errorMessages": ["boom"]}`), nil
+		}
+		return jsonResponse(http.StatusOK, searchPageJSON(startAt, searchPageSize, 1000)), nil
+	})
+
+	_, err := client.SearchIssues(context.Background(), "", "all")
+	if err == nil {
+		t.Fatal("SearchIssues() expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "Jira API error 500") {
+		t.Errorf("error = %q, want Jira API error 500", err.Error())
+	}
+}
+
+func TestSearchIssues_Sequential(t *testing.T) {
+	const total = 250
+
+	var requested []int
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		startAt, _ := strconv.Atoi(req.URL.Query().Get("startAt"))
+		requested = append(requested, startAt)
+		return jsonResponse(http.StatusOK, searchPageJSON(startAt, min(searchPageSize, total-startAt), total)), nil
+	})
+	client.concurrency = 1
+
+	issues, err := client.SearchIssues(context.Background(), "", "all")
+	if err != nil {
+		t.Fatalf("SearchIssues() error = %v", err)
+	}
+
+	assertSequentialKeys(t, issues, total)
+	if fmt.Sprint(requested) != "[0 100 200]" {
+		t.Errorf("requested offsets = %v, want [0 100 200]", requested)
+	}
+}
+
+func TestSearchIssues_CanceledBetweenPages(t *testing.T) {
+	var requested []int
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		startAt, _ := strconv.Atoi(req.URL.Query().Get("startAt"))
+		requested = append(requested, startAt)
+		return jsonResponse(http.StatusOK, searchPageJSON(startAt, searchPageSize, 500)), nil
+	})
+	client.concurrency = 1
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client.progress = func(fetched, total int) {
+		cancel() // After the first page
+	}
+
+	issues, err := client.SearchIssues(ctx, "", "all")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("SearchIssues() error = %v, want context.Canceled", err)
+	}
+	if issues != nil {
+		t.Errorf("SearchIssues() returned %d issues, want nil", len(issues))
+	}
+	if fmt.Sprint(requested) != "[0]" {
+		t.Errorf("requested offsets = %v, want [0]", requested)
+	}
+}
+
+func TestAuthHeader(t *testing.T) {
+	basic := func(user, token string) string {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+token))
+	}
+
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{
+			name: "cloud basic auth",
+			cfg:  Config{URL: "https://company.atlassian.net", Username: "me@company.com", APIToken: "api-token"},
+			want: basic("me@company.com", "api-token"),
+		},
+		{
+			name: "server basic auth",
+			cfg:  Config{URL: "https://jira.company.com", Username: "me", APIToken: "password"},
+			want: basic("me", "password"),
+		},
+		{
+			name: "server personal access token",
+			cfg:  Config{URL: "https://jira.company.com", APIToken: "pat"},
+			want: "Bearer pat",
+		},
+		{
+			name: "cloud OAuth access token",
+			cfg:  Config{URL: "https://company.atlassian.net", AccessToken: "oauth-token"},
+			want: "Bearer oauth-token",
+		},
+		{
+			name: "access token takes precedence",
+			cfg:  Config{URL: "https://company.atlassian.net", Username: "me@company.com", APIToken: "api-token", AccessToken: "oauth-token"},
+			want: "Bearer oauth-token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(tt.cfg)
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			if got := client.authHeader(); got != tt.want {
+				t.Errorf("authHeader() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewClient_RequiresToken(t *testing.T) {
+	_, err := NewClient(Config{URL: "https://company.atlassian.net", Username: "me@company.com"})
+	if err == nil {
+		t.Fatal("NewClient() expected error without API token or access token")
+	}
+
+	_, err = NewClient(Config{URL: "https://company.atlassian.net", APIToken: "api-token"})
+	if err == nil {
+		t.Fatal("NewClient() expected error for Cloud API token without username")
+	}
+}
+
+func TestValidateJQL(t *testing.T) {
+	var gotBody string
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.Method != "POST" || req.URL.Path != "/rest/api/3/jql/parse" {
+			t.Errorf("request = %s %s, want POST /rest/api/3/jql/parse", req.Method, req.URL.Path)
+		}
+		body, _ := io.ReadAll(req.Body)
+		gotBody = string(body)
+		if strings.Contains(gotBody, "statuss") {
+			return jsonResponse(http.StatusOK, `{"queries": [{"query": "project = PROJ AND statuss = Done",
+				"errors": ["Field 'statuss' does not exist or you do not have permission to view it.", "second"]}]}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"queries": [{"query": "project = PROJ", "structure": {}}]}`), nil
+	})
+
+	if err := client.ValidateJQL(context.Background(), "project = PROJ"); err != nil {
+		t.Errorf("ValidateJQL(valid) error = %v", err)
+	}
+	if gotBody != `{"queries":["project = PROJ"]}` {
+		t.Errorf("request body = %s", gotBody)
+	}
+
+	err := client.ValidateJQL(context.Background(), "project = PROJ AND statuss = Done")
+	var jqlErr *JQLError
+	if !errors.As(err, &jqlErr) {
+		t.Fatalf("ValidateJQL(invalid) error = %v, want *JQLError", err)
+	}
+	if len(jqlErr.Messages) != 2 {
+		t.Errorf("Messages = %v, want 2 entries", jqlErr.Messages)
+	}
+	want := "invalid JQL: Field 'statuss' does not exist or you do not have permission to view it."
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestJiraIssueFields_RawRoundTrip(t *testing.T) {
+	payload := `{"key": "PROJ-1", "fields": {
+		"summary": "Has custom fields",
+		"status": {"name": "In Progress"},
+		"labels": ["a", "b"],
+		"customfield_12345": {"value": "Team Rocket", "id": "10001"}
+	}}`
+
+	var issue JiraIssue
+	if err := json.Unmarshal([]byte(payload), &issue); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if issue.Fields.Summary != "Has custom fields" {
+		t.Errorf("Summary = %q", issue.Fields.Summary)
+	}
+	if issue.Fields.Status == nil || issue.Fields.Status.Name != "In Progress" {
+		t.Errorf("Status = %+v", issue.Fields.Status)
+	}
+	if len(issue.Fields.Labels) != 2 {
+		t.Errorf("Labels = %v", issue.Fields.Labels)
+	}
+
+	raw, ok := issue.Fields.Raw["customfield_12345"]
+	if !ok {
+		t.Fatalf("Raw missing customfield_12345: %v", issue.Fields.Raw)
+	}
+	var custom struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(raw, &custom); err != nil || custom.Value != "Team Rocket" {
+		t.Errorf("customfield_12345 = %s, want value Team Rocket", raw)
+	}
+
+	// Re-encode and decode again: typed and custom fields must survive
+	issue.Fields.Summary = "Edited"
+	encoded, err := json.Marshal(&issue)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var again JiraIssue
+	if err := json.Unmarshal(encoded, &again); err != nil {
+		t.Fatalf("Unmarshal(round trip) error = %v", err)
+	}
+	if again.Fields.Summary != "Edited" {
+		t.Errorf("round trip Summary = %q, want Edited", again.Fields.Summary)
+	}
+	if _, ok := again.Fields.Raw["customfield_12345"]; !ok {
+		t.Errorf("round trip lost customfield_12345: %s", encoded)
+	}
+}
+
+func TestJiraIssueFields_RoundTripPreservesPayload(t *testing.T) {
+	fields := `{
+		"summary": "Login fails on Safari",
+		"description": {"type": "doc", "version": 1, "content": [
+			{"type": "paragraph", "content": [{"type": "text", "text": "Steps to reproduce"}]}
+		]},
+		"status": {
+			"self": "https://example.atlassian.net/rest/api/3/status/10001",
+			"id": "10001",
+			"name": "In Progress",
+			"statusCategory": {"id": 4, "key": "indeterminate", "colorName": "yellow", "name": "In Progress"}
+		},
+		"priority": {
+			"self": "https://example.atlassian.net/rest/api/3/priority/2",
+			"iconUrl": "https://example.atlassian.net/images/icons/priorities/high.svg",
+			"name": "High",
+			"id": "2"
+		},
+		"issuetype": {"id": "10004", "name": "Bug", "subtask": false, "hierarchyLevel": 0, "avatarId": 10303},
+		"assignee": {"accountId": "5b10a2844c20165700ede21g", "displayName": "Alice Smith", "active": true, "timeZone": "Europe/Berlin"},
+		"reporter": {"accountId": "5b10ac8d82e05b22cc7d4ef5", "displayName": "Bob Jones", "active": true},
+		"labels": ["auth", "safari"],
+		"created": "2024-01-15T10:30:00.000+0000",
+		"updated": "2024-01-16T09:00:00.000+0000",
+		"resolution": null,
+		"resolutiondate": null,
+		"components": [{"self": "https://example.atlassian.net/rest/api/3/component/10000", "id": "10000", "name": "Web"}],
+		"fixVersions": [],
+		"votes": {"self": "https://example.atlassian.net/rest/api/3/issue/PROJ-1/votes", "votes": 3, "hasVoted": false},
+		"customfield_10016": 5.0,
+		"customfield_10019": "0|i0000f:",
+		"customfield_12345": {"value": "Team Rocket", "id": "10001"}
+	}`
+
+	var decoded JiraIssueFields
+	if err := json.Unmarshal([]byte(fields), &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var want, got map[string]any
+	if err := json.Unmarshal([]byte(fields), &want); err != nil {
+		t.Fatalf("Unmarshal(fixture) error = %v", err)
+	}
+	if err := json.Unmarshal(encoded, &got); err != nil {
+		t.Fatalf("Unmarshal(encoded) error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip changed the payload:\n got: %s\nwant: %s", encoded, fields)
+	}
+
+	// Edits to a partly modeled field keep its unmodeled keys
+	decoded.Status.Name = "Done"
+	encoded, err = json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("Marshal(edited) error = %v", err)
+	}
+	var edited struct {
+		Status map[string]any `json:"status"`
+	}
+	if err := json.Unmarshal(encoded, &edited); err != nil {
+		t.Fatalf("Unmarshal(edited) error = %v", err)
+	}
+	if edited.Status["name"] != "Done" || edited.Status["id"] != "10001" || edited.Status["statusCategory"] == nil {
+		t.Errorf("edited status = %v, want name Done with id and statusCategory kept", edited.Status)
+	}
+}
+
+func TestQuoteJQLValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"PROJ", `"PROJ"`},
+		{"MY PROJ", `"MY PROJ"`},
+		{"AND", `"AND"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`back\slash`, `"back\\slash"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := quoteJQLValue(tt.value); got != tt.want {
+				t.Errorf("quoteJQLValue(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildJQL_QuotesProject(t *testing.T) {
+	tests := []struct {
+		project string
+		want    string
+	}{
+		{"MY PROJ", `project = "MY PROJ" ORDER BY created ASC`},
+		{"AND", `project = "AND" ORDER BY created ASC`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.project, func(t *testing.T) {
+			client := newTestClient(t, "https://jira.example.com")
+			client.project = tt.project
+			got, err := client.buildJQL(SearchOptions{State: "all"})
+			if err != nil {
+				t.Fatalf("buildJQL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("buildJQL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildJQL_CustomStatusFilters(t *testing.T) {
+	client, err := NewClient(Config{
+		URL:                "https://jira.example.com",
+		Project:            "PROJ",
+		APIToken:           "token",
+		OpenStatusFilter:   `status NOT IN ("Shipped", "Abandoned")`,
+		ClosedStatusFilter: `status IN ("Shipped", "Abandoned")`,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	tests := []struct {
+		state string
+		want  string
+	}{
+		{"open", `project = "PROJ" AND (status NOT IN ("Shipped", "Abandoned")) ORDER BY created ASC`},
+		{"closed", `project = "PROJ" AND (status IN ("Shipped", "Abandoned")) ORDER BY created ASC`},
+		{"all", `project = "PROJ" ORDER BY created ASC`},
+	}
+	for _, tt := range tests {
+		got, err := client.buildJQL(SearchOptions{State: tt.state})
+		if err != nil {
+			t.Fatalf("buildJQL(%s) error = %v", tt.state, err)
+		}
+		if got != tt.want {
+			t.Errorf("buildJQL(%s) = %q, want %q", tt.state, got, tt.want)
+		}
+	}
+}
+
+func TestBuildJQL(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	since := time.Date(2024, 3, 10, 15, 45, 30, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		location *time.Location
+		opts     SearchOptions
+		want     string
+	}{
+		{
+			name: "no updated filter",
+			opts: SearchOptions{State: "all"},
+			want: `project = "PROJ" ORDER BY created ASC`,
+		},
+		{
+			name: "updated since in UTC",
+			opts: SearchOptions{State: "open", UpdatedSince: since},
+			want: `project = "PROJ" AND status != Done AND status != Closed AND updated >= "2024-03-10 15:45" ORDER BY created ASC`,
+		},
+		{
+			name:     "updated since in instance time zone",
+			location: newYork,
+			opts:     SearchOptions{UpdatedSince: since},
+			want:     `project = "PROJ" AND updated >= "2024-03-10 11:45" ORDER BY created ASC`,
+		},
+		{
+			name: "explicit JQL keeps its ordering",
+			opts: SearchOptions{JQL: "project = PROJ ORDER BY rank"},
+			want: "project = PROJ ORDER BY rank",
+		},
+		{
+			name: "explicit JQL ignores updated since",
+			opts: SearchOptions{JQL: "assignee = currentUser()", UpdatedSince: since},
+			want: "assignee = currentUser()",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, "https://jira.example.com")
+			client.location = tt.location
+			got, err := client.buildJQL(tt.opts)
+			if err != nil {
+				t.Fatalf("buildJQL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("buildJQL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildJQL_OrderBy(t *testing.T) {
+	client, err := NewClient(Config{URL: "https://jira.example.com", Project: "PROJ", APIToken: "token", OrderBy: "updated DESC"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	got, err := client.buildJQL(SearchOptions{State: "all"})
+	if err != nil {
+		t.Fatalf("buildJQL() error = %v", err)
+	}
+	if !strings.HasSuffix(got, " ORDER BY updated DESC") {
+		t.Errorf("buildJQL() = %q, want it to end with ORDER BY updated DESC", got)
+	}
+}
+
+func TestSearchIssuesWithOptions_UpdatedSince(t *testing.T) {
+	var gotJQL string
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		gotJQL = req.URL.Query().Get("jql")
+		return jsonResponse(http.StatusOK, searchPageJSON(0, 1, 1)), nil
+	})
+
+	since := time.Date(2024, 1, 2, 3, 4, 0, 0, time.UTC)
+	if _, err := client.SearchIssuesWithOptions(context.Background(), SearchOptions{UpdatedSince: since}); err != nil {
+		t.Fatalf("SearchIssuesWithOptions() error = %v", err)
+	}
+	if want := `project = "PROJ" AND updated >= "2024-01-02 03:04" ORDER BY created ASC`; gotJQL != want {
+		t.Errorf("jql = %q, want %q", gotJQL, want)
+	}
+}
+
+func TestSearchByAssignee_JQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		assignee string
+		state    string
+		want     string
+	}{
+		{"cloud accountId", "https://example.atlassian.net", "5b10ac8d82e05b22cc7d4ef5", "open",
+			`project = "PROJ" AND assignee = "5b10ac8d82e05b22cc7d4ef5" AND status != Done AND status != Closed ORDER BY created ASC`},
+		{"server username", "https://jira.example.com", "jdoe", "all",
+			`project = "PROJ" AND assignee = "jdoe" ORDER BY created ASC`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotJQL string
+			client, err := NewClient(Config{
+				URL:      tt.url,
+				Project:  "PROJ",
+				Username: "user",
+				APIToken: "token",
+				HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					gotJQL = req.URL.Query().Get("jql")
+					return jsonResponse(http.StatusOK, searchPageJSON(0, 1, 1)), nil
+				})},
+			})
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			if _, err := client.SearchByAssignee(context.Background(), tt.assignee, tt.state); err != nil {
+				t.Fatalf("SearchByAssignee() error = %v", err)
+			}
+			if gotJQL != tt.want {
+				t.Errorf("jql = %q, want %q", gotJQL, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewClient_HTTPClient(t *testing.T) {
+	client, err := NewClient(Config{URL: "https://jira.example.com", APIToken: "token"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.httpClient == nil || client.httpClient.Timeout != 0 {
+		t.Errorf("default httpClient = %+v, want no blanket timeout", client.httpClient)
+	}
+	if client.requestTimeout != DefaultRequestTimeout {
+		t.Errorf("requestTimeout = %v, want %v", client.requestTimeout, DefaultRequestTimeout)
+	}
+
+	called := false
+	injected := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		called = true
+		if req.URL.String() != "https://jira.example.com/rest/api/3/myself" {
+			t.Errorf("URL = %q", req.URL.String())
+		}
+		return jsonResponse(http.StatusOK, `{}`), nil
+	})}
+
+	client, err = NewClient(Config{URL: "https://jira.example.com", APIToken: "token", HTTPClient: injected})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.httpClient != injected {
+		t.Error("httpClient is not the injected client")
+	}
+
+	resp, err := client.doRequest(context.Background(), "GET", "/rest/api/3/myself", nil)
+	if err != nil {
+		t.Fatalf("doRequest() error = %v", err)
+	}
+	resp.Body.Close()
+	if !called {
+		t.Error("injected transport was not used")
+	}
+}
+
+func TestDoRequest_Timeouts(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	t.Run("context deadline", func(t *testing.T) {
+		client := newTestClient(t, server.URL)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := client.doRequest(ctx, "GET", "/rest/api/3/myself", nil)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("doRequest() error = %v, want deadline exceeded", err)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("doRequest() took %v, want it cancelled by the context deadline", elapsed)
+		}
+	})
+
+	t.Run("request timeout", func(t *testing.T) {
+		client, err := NewClient(Config{URL: server.URL, APIToken: "token", RequestTimeout: 50 * time.Millisecond})
+		if err != nil {
+			t.Fatalf("NewClient() error = %v", err)
+		}
+
+		// The configured timeout is tighter than the context's deadline
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		_, err = client.doRequest(ctx, "GET", "/rest/api/3/myself", nil)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("doRequest() error = %v, want deadline exceeded", err)
+		}
+	})
+}
+
+func TestPing(t *testing.T) {
+	t.Run("authorized", func(t *testing.T) {
+		client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/rest/api/3/myself" {
+				t.Errorf("unexpected path %q", req.URL.Path)
+			}
+			return jsonResponse(http.StatusOK, `{"accountId": "abc123", "displayName": "Alice Smith", "emailAddress": "alice@example.com"}`), nil
+		})
+
+		if err := client.Ping(context.Background()); err != nil {
+			t.Fatalf("Ping() error = %v", err)
+		}
+		user, err := client.CurrentUser(context.Background())
+		if err != nil {
+			t.Fatalf("CurrentUser() error = %v", err)
+		}
+		if user.GetDisplayName() != "Alice Smith" || user.AccountID != "abc123" {
+			t.Errorf("CurrentUser() = %+v, want Alice Smith (abc123)", user)
+		}
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusUnauthorized, ``), nil
+		})
+
+		err := client.Ping(context.Background())
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.Kind != ErrorKindUnauthorized {
+			t.Fatalf("Ping() error = %v, want unauthorized APIError", err)
+		}
+		if !strings.Contains(err.Error(), "Authentication failed") {
+			t.Errorf("Ping() error = %q, want authentication guidance", err)
+		}
+	})
+}
+
+func TestNormalizeBaseURL(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"https://company.atlassian.net", "https://company.atlassian.net", false},
+		{"https://company.atlassian.net/", "https://company.atlassian.net", false},
+		{"https://tools.corp.com/jira", "https://tools.corp.com/jira", false},
+		{"https://tools.corp.com/jira//", "https://tools.corp.com/jira", false},
+		{"https://tools.corp.com/jira/?os_authType=basic#top", "https://tools.corp.com/jira", false},
+		{"tools.corp.com/jira", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := normalizeBaseURL(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeBaseURL(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeBaseURL(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ContextPath(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"displayName": "Alice"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL+"/jira/")
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	if gotPath != "/jira/rest/api/3/myself" {
+		t.Errorf("request path = %q, want /jira/rest/api/3/myself", gotPath)
+	}
+}
+
+func TestClient_APIVersion(t *testing.T) {
+	tests := []struct {
+		version    string
+		wantSearch string
+		wantIssue  string
+	}{
+		{"", "/rest/api/3/search/jql", "/rest/api/3/issue/PROJ-1"},
+		{"3", "/rest/api/3/search/jql", "/rest/api/3/issue/PROJ-1"},
+		{"2", "/rest/api/2/search", "/rest/api/2/issue/PROJ-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run("v"+tt.version, func(t *testing.T) {
+			var paths []string
+			client, err := NewClient(Config{
+				URL:        "https://jira.example.com",
+				Project:    "PROJ",
+				APIToken:   "token",
+				APIVersion: tt.version,
+				HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					paths = append(paths, req.URL.Path)
+					if strings.Contains(req.URL.Path, "/search") {
+						return jsonResponse(http.StatusOK, searchPageJSON(0, 1, 1)), nil
+					}
+					return jsonResponse(http.StatusOK, `{"key": "PROJ-1", "fields": {}}`), nil
+				})},
+			})
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			if _, err := client.SearchIssues(context.Background(), "", "all"); err != nil {
+				t.Fatalf("SearchIssues() error = %v", err)
+			}
+			if _, err := client.GetIssue(context.Background(), "PROJ-1"); err != nil {
+				t.Fatalf("GetIssue() error = %v", err)
+			}
+			if want := []string{tt.wantSearch, tt.wantIssue}; !reflect.DeepEqual(paths, want) {
+				t.Errorf("paths = %v, want %v", paths, want)
+			}
+		})
+	}
+
+	if _, err := NewClient(Config{URL: "https://jira.example.com", APIToken: "token", APIVersion: "4"}); err == nil {
+		t.Error("NewClient() with APIVersion 4 expected error, got nil")
+	}
+}
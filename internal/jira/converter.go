@@ -1,36 +1,44 @@
 package jira
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/steveyegge/beads/internal/types"
+	"golang.org/x/text/unicode/norm"
 )
 
 // DefaultStatusMapping maps Jira status names (lowercase) to bd status values.
 var DefaultStatusMapping = map[string]types.Status{
-	"to do":             types.StatusOpen,
-	"todo":              types.StatusOpen,
-	"open":              types.StatusOpen,
-	"backlog":           types.StatusOpen,
-	"new":               types.StatusOpen,
-	"in progress":       types.StatusInProgress,
-	"in development":    types.StatusInProgress,
-	"in review":         types.StatusInProgress,
-	"review":            types.StatusInProgress,
-	"blocked":           types.StatusBlocked,
-	"on hold":           types.StatusBlocked,
-	"done":              types.StatusClosed,
-	"closed":            types.StatusClosed,
-	"resolved":          types.StatusClosed,
-	"complete":          types.StatusClosed,
-	"completed":         types.StatusClosed,
-	"won't do":          types.StatusClosed,
-	"won't fix":         types.StatusClosed,
-	"duplicate":         types.StatusClosed,
-	"cannot reproduce":  types.StatusClosed,
+	"to do":            types.StatusOpen,
+	"todo":             types.StatusOpen,
+	"open":             types.StatusOpen,
+	"backlog":          types.StatusOpen,
+	"new":              types.StatusOpen,
+	"in progress":      types.StatusInProgress,
+	"in development":   types.StatusInProgress,
+	"in review":        types.StatusInProgress,
+	"review":           types.StatusInProgress,
+	"blocked":          types.StatusBlocked,
+	"on hold":          types.StatusBlocked,
+	"done":             types.StatusClosed,
+	"closed":           types.StatusClosed,
+	"resolved":         types.StatusClosed,
+	"complete":         types.StatusClosed,
+	"completed":        types.StatusClosed,
+	"won't do":         types.StatusClosed,
+	"won't fix":        types.StatusClosed,
+	"duplicate":        types.StatusClosed,
+	"cannot reproduce": types.StatusClosed,
 }
 
 // DefaultTypeMapping maps Jira issue type names (lowercase) to bd issue types.
@@ -53,6 +61,24 @@ var DefaultTypeMapping = map[string]types.IssueType{
 	"chore":          types.TypeChore,
 }
 
+// SubtaskTypeRule maps subtask issue types whose lowercase name contains
+// Substring to Type, so names like "Bug Sub-task" keep their meaning.
+type SubtaskTypeRule struct {
+	Substring string
+	Type      types.IssueType
+}
+
+// DefaultSubtaskTypeRules are the substring rules applied to subtask types
+// with no exact mapping. The first matching rule wins.
+var DefaultSubtaskTypeRules = []SubtaskTypeRule{
+	{"bug", types.TypeBug},
+	{"defect", types.TypeBug},
+	{"story", types.TypeFeature},
+	{"feature", types.TypeFeature},
+	{"chore", types.TypeChore},
+	{"technical", types.TypeChore},
+}
+
 // DefaultPriorityMapping maps Jira priority names (lowercase) to bd priority (0-4).
 var DefaultPriorityMapping = map[string]int{
 	"highest":  0,
@@ -68,26 +94,203 @@ var DefaultPriorityMapping = map[string]int{
 	"trivial":  4,
 }
 
+// DefaultJiraStatusNames maps bd statuses to Jira status names for write-back.
+// Jira statuses can only be changed through workflow transitions.
+var DefaultJiraStatusNames = map[types.Status]string{
+	types.StatusOpen:       "To Do",
+	types.StatusInProgress: "In Progress",
+	types.StatusBlocked:    "Blocked",
+	types.StatusDeferred:   "Backlog",
+	types.StatusClosed:     "Done",
+}
+
+// DefaultJiraTypeNames maps bd issue types to Jira issue type names for write-back.
+var DefaultJiraTypeNames = map[types.IssueType]string{
+	types.TypeBug:     "Bug",
+	types.TypeFeature: "Story",
+	types.TypeTask:    "Task",
+	types.TypeEpic:    "Epic",
+	types.TypeChore:   "Task",
+}
+
+// DefaultJiraPriorityNames maps bd priorities (0-4) to Jira priority names for write-back.
+var DefaultJiraPriorityNames = map[int]string{
+	0: "Highest",
+	1: "High",
+	2: "Medium",
+	3: "Low",
+	4: "Lowest",
+}
+
+// DefaultBlockingLinkTypes lists the Jira link type names (lowercase) that are
+// treated as blocking dependencies.
+var DefaultBlockingLinkTypes = []string{"blocks"}
+
 // Converter converts Jira issues to bd issues.
 type Converter struct {
-	jiraURL        string
-	prefix         string
-	statusMap      map[string]types.Status
-	typeMap        map[string]types.IssueType
-	priorityMap    map[string]int
-	jiraKeyToBDID  map[string]string // Maps Jira keys to bd IDs for dependency resolution
-	idGenerator    func(title string, timestamp time.Time) (string, error)
+	jiraURL             string
+	prefix              string
+	projectPrefixes     map[string]string
+	statusMap           map[string]types.Status // Overrides consulted before DefaultStatusMapping
+	typeMap             map[string]types.IssueType
+	subtaskTypeRules    []SubtaskTypeRule
+	priorityMap         map[string]int    // Overrides consulted before DefaultPriorityMapping
+	priorityByID        map[string]int    // Consulted before any name mapping
+	blockingLinkTypes   map[string]bool   // Lowercase link type names that map to DepBlocks
+	jiraKeyToBDID       map[string]string // Maps Jira keys to bd IDs for dependency resolution
+	counter             int               // Fallback sequential ID counter if no ID generator provided
+	sprintFieldID       string
+	storyPointsField    string
+	epicLinkField       string
+	epicNameField       string
+	componentsLabels    bool
+	namespacedTags      bool
+	fixVersionMilestone bool
+	adf                 adfRenderer // Renders ADF descriptions with any custom node handlers
+	labelTransform      func(string) (string, bool)
+	defaultAssignee     string
+	defaultReporter     string
+	idFromKey           bool
+	maxDescriptionLen   int
+	normalizeText       bool
+	epicHandling        string
+	flaggedField        string
+	teamField           string
+	rankField           string
+	flaggedClosed       bool
+	backlogStatuses     map[string]bool // Lowercase Jira status names that are backlog, not ready
+	backlogStatus       types.Status
+	backlogLabel        string
+	excludedEpics       map[string]string // Jira keys of epics kept out of the issues, to milestone name
+	skipTypes           map[string]bool   // Lowercase Jira issue type names to leave out
+	skipped             map[string]bool   // Jira keys of issues left out by skipTypes
+	idGenerator         func(title string, timestamp time.Time) (string, error)
 }
 
 // ConverterConfig holds configuration for the converter.
 type ConverterConfig struct {
-	JiraURL     string
-	Prefix      string // ID prefix (default: "bd")
-	StatusMap   map[string]types.Status
-	TypeMap     map[string]types.IssueType
+	JiraURL string
+	Prefix  string // ID prefix (default: "bd")
+	// PrefixByProject maps Jira project keys (the "PROJ" in "PROJ-123") to ID
+	// prefixes, so multi-project imports get distinct IDs. Projects not
+	// listed use Prefix. Ignored when IDGenerator is set.
+	PrefixByProject map[string]string
+	// StatusMap maps Jira status names to bd statuses. Keys are matched
+	// case-insensitively and consulted before DefaultStatusMapping, so custom
+	// workflow states (e.g. "Ready for QA") can be mapped without losing the
+	// built-in defaults.
+	StatusMap map[string]types.Status
+	TypeMap   map[string]types.IssueType
+	// SubtaskTypeRules map subtask types that have no TypeMap entry by
+	// substring (e.g. "Bug Sub-task" to bug); Issue.IsSubtask is set either
+	// way. Defaults to DefaultSubtaskTypeRules; an empty slice disables them.
+	SubtaskTypeRules []SubtaskTypeRule
+	// PriorityMap maps Jira priority names to bd priorities (0-4). Keys are
+	// matched case-insensitively and consulted before DefaultPriorityMapping,
+	// so custom schemes like "P0".."P4" can be supported.
 	PriorityMap map[string]int
+	// PriorityByID maps Jira priority IDs (e.g. "1" for Highest on a default
+	// scheme) to bd priorities. It is consulted before any name mapping, so
+	// localized instances whose priority names vary by language can map reliably.
+	PriorityByID map[string]int
+	// BlockingLinkTypes lists Jira link type names (case-insensitive) that
+	// become blocking dependencies. Other link types become related
+	// dependencies. Defaults to DefaultBlockingLinkTypes.
+	BlockingLinkTypes []string
+	// SprintFieldID is the custom field holding sprint data (e.g.
+	// "customfield_10020"). If empty, only the Agile API "sprint" field is used.
+	SprintFieldID string
+	// StoryPointsFieldID is the custom field holding story points (commonly
+	// "customfield_10016"). The value is copied to Issue.Estimate.
+	StoryPointsFieldID string
+	// EpicLinkFieldID is the "Epic Link" custom field used by Server/DC and
+	// older Cloud sites to point at an issue's epic. When set and non-empty
+	// on an issue, it is used as the parent instead of the parent field.
+	EpicLinkFieldID string
+	// EpicNameFieldID is the "Epic Name" custom field; its value is copied
+	// to Issue.EpicName on epics.
+	EpicNameFieldID string
+	// ComponentsAsLabels adds Jira components to Issue.Labels with a
+	// "component:" prefix instead of setting Issue.Components.
+	ComponentsAsLabels bool
+	// NamespacedTags replaces Issue.Labels with a flat tag set recording
+	// each tag's origin: "label:foo" for Jira labels (after LabelTransform),
+	// "component:bar" for components, and "version:1.2" for fix versions.
+	// It takes precedence over ComponentsAsLabels. Components and
+	// FixVersions are still set.
+	NamespacedTags bool
+	// FixVersionAsMilestone sets Issue.Milestone from the fix versions,
+	// preferring the first unreleased one.
+	FixVersionAsMilestone bool
+	// ADFNodeHandlers renders ADF node types (e.g. "mediaSingle", "status")
+	// that the built-in extractor ignores or renders differently. Keys are
+	// ADF node type names.
+	ADFNodeHandlers map[string]ADFNodeHandler
+	// ADFListStyle sets the bullet, number format, and indentation used for
+	// lists in ADF descriptions. Empty fields use DefaultADFListStyle.
+	ADFListStyle ADFListStyle
+	// LabelTransform is applied to each Jira label. It returns the label to
+	// keep, or false to drop it, allowing labels to be lowercased, remapped,
+	// or filtered. Order is preserved and duplicates produced by the
+	// transform are removed.
+	LabelTransform func(label string) (string, bool)
+	// DefaultAssignee and DefaultReporter are used for Issue.Assignee and
+	// Issue.CreatedBy when the Jira issue has no assignee or reporter.
+	// They never override a user that is present.
+	DefaultAssignee string
+	DefaultReporter string
+	// MaxDescriptionLength caps the rendered description at this many runes.
+	// A cut description ends in "…", which counts toward the limit. Zero
+	// means unlimited.
+	MaxDescriptionLength int
+	// NormalizeText cleans up text pasted from word processors in
+	// descriptions and environments: smart quotes become ASCII quotes,
+	// non-breaking spaces become spaces, zero-width characters are removed,
+	// and the result is NFC-normalized.
+	NormalizeText bool
+	// TeamFieldID is the Advanced Roadmaps "Team" custom field. Its team
+	// name, or ID when no name is given, is copied to Issue.Team.
+	TeamFieldID string
+	// RankFieldID is the "Rank" custom field holding the LexoRank string
+	// that orders the backlog (commonly "customfield_10019"). Its value is
+	// copied to Issue.Rank; use SortByRank to restore the order.
+	RankFieldID string
+	// FlaggedFieldID is the "Flagged" custom field whose "Impediment" value
+	// marks an issue as blocked. The mapped status is applied first; a flag
+	// then sets Issue.Flagged and turns any status other than closed into
+	// blocked. Set FlaggedOverridesClosed to let a flag reopen closed issues
+	// as blocked too.
+	FlaggedFieldID         string
+	FlaggedOverridesClosed bool
+	// BacklogStatuses lists Jira status names (case-insensitive), such as
+	// "Backlog", that mean not yet ready, as distinct from statuses like
+	// "To Do" that mean ready to start. Both usually map to open; matching
+	// issues are tagged with BacklogLabel (default "backlog"; "label:backlog"
+	// with NamespacedTags) and, when BacklogStatus is set, given that status
+	// instead of the mapped one.
+	BacklogStatuses []string
+	BacklogStatus   types.Status
+	BacklogLabel    string
+	// EpicHandling controls how the Convert* methods treat issues that map
+	// to the epic type: EpicAsIssue (the default) converts them like any
+	// other issue, EpicSkip drops them, and EpicAsMilestone drops them but
+	// sets Issue.Milestone on their children, with the milestones themselves
+	// returned in ConversionResult.Milestones by ConvertWithDependencies.
+	// Links to dropped epics are ignored rather than reported as unresolved.
+	// ConvertOne always converts epics as issues.
+	EpicHandling string
+	// SkipIssueTypes lists Jira issue type names (case-insensitive), such as
+	// "Test", whose issues every Convert* method leaves out.
+	// Links and parents pointing at skipped issues are ignored rather than
+	// reported as unresolved.
+	SkipIssueTypes []string
+	// IDFromKey derives IDs from the Jira key and prefix (e.g. "bd-PROJ-123")
+	// instead of sequential placeholders, so re-imports produce identical
+	// IDs. Ignored when IDGenerator is set.
+	IDFromKey bool
 	// IDGenerator generates a bd ID. If nil, a simple incrementing ID is used.
-	// The function should return an ID in the format "prefix-xxx".
+	// The function should return an ID in the format "prefix-xxx", and must
+	// be safe for concurrent use if ConvertConcurrent is used.
 	IDGenerator func(title string, timestamp time.Time) (string, error)
 }
 
@@ -98,63 +301,306 @@ func NewConverter(cfg ConverterConfig) *Converter {
 		prefix = "bd"
 	}
 
-	statusMap := cfg.StatusMap
-	if statusMap == nil {
-		statusMap = DefaultStatusMapping
-	}
-
 	typeMap := cfg.TypeMap
 	if typeMap == nil {
 		typeMap = DefaultTypeMapping
 	}
 
-	priorityMap := cfg.PriorityMap
-	if priorityMap == nil {
-		priorityMap = DefaultPriorityMapping
+	subtaskTypeRules := cfg.SubtaskTypeRules
+	if subtaskTypeRules == nil {
+		subtaskTypeRules = DefaultSubtaskTypeRules
+	}
+
+	blockingLinkTypes := cfg.BlockingLinkTypes
+	if blockingLinkTypes == nil {
+		blockingLinkTypes = DefaultBlockingLinkTypes
+	}
+	blockingSet := make(map[string]bool, len(blockingLinkTypes))
+	for _, name := range blockingLinkTypes {
+		blockingSet[strings.ToLower(name)] = true
+	}
+
+	skipTypes := make(map[string]bool, len(cfg.SkipIssueTypes))
+	for _, name := range cfg.SkipIssueTypes {
+		skipTypes[strings.ToLower(name)] = true
+	}
+
+	backlogSet := make(map[string]bool, len(cfg.BacklogStatuses))
+	for _, name := range cfg.BacklogStatuses {
+		backlogSet[strings.ToLower(name)] = true
+	}
+	backlogLabel := cfg.BacklogLabel
+	if backlogLabel == "" {
+		backlogLabel = "backlog"
 	}
 
 	return &Converter{
-		jiraURL:       strings.TrimSuffix(cfg.JiraURL, "/"),
-		prefix:        prefix,
-		statusMap:     statusMap,
-		typeMap:       typeMap,
-		priorityMap:   priorityMap,
-		jiraKeyToBDID: make(map[string]string),
-		idGenerator:   cfg.IDGenerator,
+		jiraURL:             strings.TrimSuffix(cfg.JiraURL, "/"),
+		prefix:              prefix,
+		projectPrefixes:     cfg.PrefixByProject,
+		statusMap:           lowercaseKeys(cfg.StatusMap),
+		typeMap:             typeMap,
+		subtaskTypeRules:    subtaskTypeRules,
+		priorityMap:         lowercaseKeys(cfg.PriorityMap),
+		priorityByID:        cfg.PriorityByID,
+		blockingLinkTypes:   blockingSet,
+		jiraKeyToBDID:       make(map[string]string),
+		idGenerator:         cfg.IDGenerator,
+		sprintFieldID:       cfg.SprintFieldID,
+		storyPointsField:    cfg.StoryPointsFieldID,
+		epicLinkField:       cfg.EpicLinkFieldID,
+		epicNameField:       cfg.EpicNameFieldID,
+		componentsLabels:    cfg.ComponentsAsLabels,
+		namespacedTags:      cfg.NamespacedTags,
+		fixVersionMilestone: cfg.FixVersionAsMilestone,
+		adf:                 adfRenderer{handlers: cfg.ADFNodeHandlers, lists: cfg.ADFListStyle},
+		labelTransform:      cfg.LabelTransform,
+		defaultAssignee:     cfg.DefaultAssignee,
+		defaultReporter:     cfg.DefaultReporter,
+		idFromKey:           cfg.IDFromKey,
+		maxDescriptionLen:   cfg.MaxDescriptionLength,
+		normalizeText:       cfg.NormalizeText,
+		epicHandling:        cfg.EpicHandling,
+		flaggedField:        cfg.FlaggedFieldID,
+		teamField:           cfg.TeamFieldID,
+		rankField:           cfg.RankFieldID,
+		flaggedClosed:       cfg.FlaggedOverridesClosed,
+		backlogStatuses:     backlogSet,
+		backlogStatus:       cfg.BacklogStatus,
+		backlogLabel:        backlogLabel,
+		excludedEpics:       make(map[string]string),
+		skipTypes:           skipTypes,
+		skipped:             make(map[string]bool),
 	}
 }
 
+// ConversionResult holds the output of ConvertWithDependencies.
+type ConversionResult struct {
+	Issues       []*types.Issue
+	Dependencies []*types.Dependency   // All dependency edges, keyed by bd IDs
+	Unresolved   []UnresolvedReference // References to issues outside the batch
+	Events       []*types.Event        // Status transitions from the Jira changelog
+	Milestones   []*Milestone          // Epics converted with EpicAsMilestone
+}
+
+// UnresolvedReference records a Jira issue reference that could not be mapped
+// to a bd ID because the referenced issue was not part of the converted batch.
+type UnresolvedReference struct {
+	IssueKey  string // Jira key of the issue holding the reference
+	TargetKey string // Jira key that could not be resolved
+	LinkType  string // Jira link type name, or ParentLinkType for parents
+}
+
+// ParentLinkType is the UnresolvedReference.LinkType used for parent issues.
+const ParentLinkType = "parent"
+
+// String returns a human-readable warning for the unresolved reference.
+func (r UnresolvedReference) String() string {
+	return fmt.Sprintf("%s: %q link to %s not resolved (issue not imported)", r.IssueKey, r.LinkType, r.TargetKey)
+}
+
 // Convert transforms a slice of Jira issues into bd issues.
 // Returns the converted issues and any dependencies discovered.
 func (c *Converter) Convert(jiraIssues []*JiraIssue) ([]*types.Issue, error) {
-	// First pass: convert all issues and build key-to-ID mapping
-	bdIssues := make([]*types.Issue, 0, len(jiraIssues))
+	result, err := c.ConvertWithDependencies(jiraIssues)
+	if err != nil {
+		return nil, err
+	}
+	return result.Issues, nil
+}
 
-	counter := 1 // Fallback counter if no ID generator provided
+// ConvertWithDependencies transforms Jira issues into bd issues and also
+// returns the dependency edges derived from Jira issue links, keyed by the
+// generated bd IDs. Links to issues outside the batch are reported as
+// unresolved references rather than failing the conversion.
+func (c *Converter) ConvertWithDependencies(jiraIssues []*JiraIssue) (*ConversionResult, error) {
+	// First pass: convert all issues and build key-to-ID mapping
+	converted, milestones := c.partition(jiraIssues)
+	bdIssues := make([]*types.Issue, 0, len(converted))
+	result := &ConversionResult{Milestones: milestones}
 
-	for _, jira := range jiraIssues {
-		bdIssue, err := c.convertIssue(jira, &counter)
+	for _, jira := range converted {
+		bdIssue, err := c.ConvertOne(jira)
 		if err != nil {
-			return nil, fmt.Errorf("converting issue %s: %w", jira.Key, err)
+			return nil, err
 		}
-		c.jiraKeyToBDID[jira.Key] = bdIssue.ID
 		bdIssues = append(bdIssues, bdIssue)
 	}
 
-	// Second pass: resolve dependencies
-	for i, jira := range jiraIssues {
-		deps := c.extractDependencies(jira)
+	result.Issues = bdIssues
+
+	// Second pass: resolve dependencies and parents
+	for i, jira := range converted {
+		c.resolveParent(jira, bdIssues[i])
+		deps, unresolved := c.extractDependencies(jira)
 		if len(deps) > 0 {
 			bdIssues[i].Dependencies = deps
+			result.Dependencies = append(result.Dependencies, deps...)
+		}
+		result.Unresolved = append(result.Unresolved, unresolved...)
+		result.Events = append(result.Events, c.extractStatusEvents(jira, bdIssues[i].ID)...)
+	}
+
+	return result, nil
+}
+
+// partition returns the issues to convert, leaving out those matching
+// SkipIssueTypes and the epics EpicHandling excludes. Their keys are
+// recorded so links to them are ignored. Epics converted with
+// EpicAsMilestone are returned as milestones. Every conversion entry point
+// filters through it.
+func (c *Converter) partition(jiraIssues []*JiraIssue) ([]*JiraIssue, []*Milestone) {
+	kept := make([]*JiraIssue, 0, len(jiraIssues))
+	var milestones []*Milestone
+	for _, jira := range jiraIssues {
+		switch {
+		case c.skipsType(jira):
+			c.skipped[jira.Key] = true
+		case c.excludesEpic(jira):
+			milestone := c.convertMilestone(jira)
+			c.excludedEpics[jira.Key] = milestone.Name
+			if c.epicHandling == EpicAsMilestone {
+				milestones = append(milestones, milestone)
+			}
+		default:
+			kept = append(kept, jira)
+		}
+	}
+	return kept, milestones
+}
+
+// resolveParent sets issue.ParentID from the converted batch, or
+// issue.Milestone if the parent epic was converted to a milestone. Parents
+// that were left out are ignored.
+func (c *Converter) resolveParent(jira *JiraIssue, issue *types.Issue) {
+	if name, ok := c.parentMilestone(jira); ok {
+		issue.Milestone = name
+		return
+	}
+	if parentKey := c.parentKey(jira); parentKey != "" && !c.isLeftOut(parentKey) {
+		issue.ParentID = c.jiraKeyToBDID[parentKey]
+	}
+}
+
+// parentMilestone returns the milestone name of jira's parent epic if it was
+// converted with EpicAsMilestone.
+func (c *Converter) parentMilestone(jira *JiraIssue) (string, bool) {
+	parentKey := c.parentKey(jira)
+	if parentKey == "" || c.epicHandling != EpicAsMilestone {
+		return "", false
+	}
+	name, ok := c.excludedEpics[parentKey]
+	return name, ok
+}
+
+// skipsType reports whether jira's issue type is one of SkipIssueTypes.
+func (c *Converter) skipsType(jira *JiraIssue) bool {
+	issueType := jira.Fields.IssueType
+	return issueType != nil && c.skipTypes[strings.ToLower(issueType.Name)]
+}
+
+// isLeftOut reports whether the issue with the given Jira key was kept out
+// of the converted issues, as an excluded epic or a skipped type.
+func (c *Converter) isLeftOut(key string) bool {
+	_, excluded := c.excludedEpics[key]
+	return excluded || c.skipped[key]
+}
+
+// ConvertOne transforms a single Jira issue into a bd issue and records its
+// Jira key to bd ID mapping. Dependencies are not resolved here since they
+// need the rest of the batch; use Convert or ConvertWithDependencies for that.
+func (c *Converter) ConvertOne(jira *JiraIssue) (*types.Issue, error) {
+	c.counter++
+	bdIssue, err := c.convertIssue(jira, c.counter)
+	if err != nil {
+		return nil, fmt.Errorf("converting issue %s: %w", jira.Key, err)
+	}
+	c.jiraKeyToBDID[jira.Key] = bdIssue.ID
+	return bdIssue, nil
+}
+
+// ConvertStream converts Jira issues one at a time and writes each bd issue
+// to w as a line of JSON, so large imports need not be held in memory.
+// SkipIssueTypes and EpicHandling are applied as in Convert, including
+// setting Issue.Milestone, but epics converted to milestones are not
+// written. Dependencies and parent IDs are not resolved, since a parent may
+// come later in the stream. It stops at the first conversion or write error
+// and checks ctx for cancellation between issues.
+func (c *Converter) ConvertStream(ctx context.Context, jiraIssues []*JiraIssue, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	converted, _ := c.partition(jiraIssues)
+	for _, jira := range converted {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		bdIssue, err := c.ConvertOne(jira)
+		if err != nil {
+			return err
+		}
+		if name, ok := c.parentMilestone(jira); ok {
+			bdIssue.Milestone = name
+		}
+		if err := enc.Encode(bdIssue); err != nil {
+			return fmt.Errorf("writing issue %s: %w", jira.Key, err)
 		}
 	}
+	return nil
+}
+
+// ConvertConcurrent converts Jira issues across a pool of workers and returns
+// them in input order. It produces the same issues as Convert, including
+// SkipIssueTypes, EpicHandling, ParentID, and Milestone, except that
+// Issue.Dependencies are not resolved; epics converted to milestones are not
+// returned, so use ConvertWithDependencies if those are needed. It is
+// intended for expensive IDGenerator functions: the generator and any other
+// configured callbacks (LabelTransform, ADFNodeHandlers) must be safe for
+// concurrent use. A workers value below 1 converts sequentially. The first
+// error is returned.
+func (c *Converter) ConvertConcurrent(jiraIssues []*JiraIssue, workers int) ([]*types.Issue, error) {
+	jiraIssues, _ = c.partition(jiraIssues)
+	workers = max(1, min(workers, len(jiraIssues)))
+
+	// Reserve fallback sequence numbers up front so IDs match a serial run
+	base := c.counter
+	c.counter += len(jiraIssues)
+
+	bdIssues := make([]*types.Issue, len(jiraIssues))
+	errs := make([]error, len(jiraIssues))
+	indexes := make(chan int)
 
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				bdIssues[i], errs[i] = c.convertIssue(jiraIssues[i], base+i+1)
+			}
+		}()
+	}
+	for i := range jiraIssues {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i, jira := range jiraIssues {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("converting issue %s: %w", jira.Key, errs[i])
+		}
+		c.jiraKeyToBDID[jira.Key] = bdIssues[i].ID
+	}
+	for i, jira := range jiraIssues {
+		c.resolveParent(jira, bdIssues[i])
+	}
 	return bdIssues, nil
 }
 
-// convertIssue converts a single Jira issue to a bd issue.
-// If IDGenerator is nil, ID is left empty and must be generated by the import logic.
-func (c *Converter) convertIssue(jira *JiraIssue, counter *int) (*types.Issue, error) {
+// convertIssue converts a single Jira issue to a bd issue. seq numbers the
+// placeholder ID used when no IDGenerator is configured.
+// If IDGenerator is nil and there is no prefix, ID is left empty and must be
+// generated by the import logic.
+func (c *Converter) convertIssue(jira *JiraIssue, seq int) (*types.Issue, error) {
 	// Parse timestamps
 	createdAt, err := parseJiraTimestamp(jira.Fields.Created)
 	if err != nil {
@@ -173,15 +619,25 @@ func (c *Converter) convertIssue(jira *JiraIssue, counter *int) (*types.Issue, e
 		if err != nil {
 			return nil, fmt.Errorf("generating ID: %w", err)
 		}
-	} else if c.prefix != "" {
+	} else if c.idFromKey && jira.Key != "" {
+		// Stable across runs, so dependency mapping survives re-imports
+		id = c.prefixFor(jira.Key) + "-" + jira.Key
+	} else if prefix := c.prefixFor(jira.Key); prefix != "" {
 		// Use simple sequential IDs as placeholders - import logic will regenerate
-		id = fmt.Sprintf("%s-%d", c.prefix, *counter)
-		*counter++
+		id = fmt.Sprintf("%s-%d", prefix, seq)
 	}
 	// If both are nil/empty, ID will be generated by import logic
 
 	// Map fields
 	status := c.mapStatus(jira.Fields.Status)
+	backlog := c.isBacklog(jira.Fields.Status)
+	if backlog && c.backlogStatus != "" {
+		status = c.backlogStatus
+	}
+	flagged := c.flaggedField != "" && isFlagged(jira.Fields.Raw[c.flaggedField])
+	if flagged && (status != types.StatusClosed || c.flaggedClosed) {
+		status = types.StatusBlocked
+	}
 	issueType := c.mapIssueType(jira.Fields.IssueType)
 	priority := c.mapPriority(jira.Fields.Priority)
 
@@ -189,15 +645,16 @@ func (c *Converter) convertIssue(jira *JiraIssue, counter *int) (*types.Issue, e
 	externalRef := fmt.Sprintf("%s/browse/%s", c.jiraURL, jira.Key)
 
 	// Get reporter/creator
-	createdBy := ""
+	createdBy, reporterID := c.defaultReporter, ""
 	if jira.Fields.Reporter != nil {
 		createdBy = jira.Fields.Reporter.GetDisplayName()
+		reporterID = jira.Fields.Reporter.GetID()
 	}
 
 	issue := &types.Issue{
 		ID:          id,
 		Title:       jira.Fields.Summary,
-		Description: jira.Fields.GetDescription(),
+		Description: truncateRunes(c.renderText(jira.Fields.Description), c.maxDescriptionLen),
 		Status:      status,
 		Priority:    priority,
 		IssueType:   issueType,
@@ -205,28 +662,299 @@ func (c *Converter) convertIssue(jira *JiraIssue, counter *int) (*types.Issue, e
 		CreatedBy:   createdBy,
 		UpdatedAt:   updatedAt,
 		ExternalRef: &externalRef,
-		Labels:      jira.Fields.Labels,
+		Labels:      c.transformLabels(jira.Fields.Labels),
+		ReporterID:  reporterID,
+	}
+
+	issue.Flagged = flagged
+	issue.ParentIsEpic = c.parentIsEpic(jira)
+	if c.teamField != "" {
+		issue.Team = parseTeamField(jira.Fields.Raw[c.teamField])
+	}
+
+	// Keep the original status, which mapping may collapse (e.g. Resolved and Closed)
+	if jira.Fields.Status != nil {
+		issue.SourceStatus = jira.Fields.Status.Name
+	}
+
+	// Flag subtasks, which otherwise map to an ordinary task type
+	if jira.Fields.IssueType != nil && jira.Fields.IssueType.Subtask {
+		issue.IsSubtask = true
 	}
 
-	// Set assignee
+	// Set assignee, falling back to the configured default
 	if jira.Fields.Assignee != nil {
 		issue.Assignee = jira.Fields.Assignee.GetDisplayName()
+		issue.AssigneeID = jira.Fields.Assignee.GetID()
+	} else {
+		issue.Assignee = c.defaultAssignee
 	}
 
-	// Set closed_at if resolved
-	if status == types.StatusClosed && jira.Fields.ResolutionDate != "" {
-		closedAt, err := parseJiraTimestamp(jira.Fields.ResolutionDate)
-		if err == nil {
-			issue.ClosedAt = &closedAt
+	// Set closed_at and close_reason if resolved
+	if status == types.StatusClosed {
+		if jira.Fields.ResolutionDate != "" {
+			closedAt, err := parseJiraTimestamp(jira.Fields.ResolutionDate)
+			if err == nil {
+				issue.ClosedAt = &closedAt
+			}
+		}
+		if jira.Fields.Resolution != nil {
+			issue.CloseReason = jira.Fields.Resolution.Name
+		}
+	}
+
+	// Set sprint from the configured custom field or the Agile API field
+	if sprint := c.extractSprint(jira); sprint != nil {
+		issue.Sprint = sprint.Name
+		issue.SprintState = sprint.State
+	}
+
+	// Set attachment metadata
+	if len(jira.Fields.Attachments) > 0 {
+		issue.AttachmentCount = len(jira.Fields.Attachments)
+		for _, att := range jira.Fields.Attachments {
+			issue.Attachments = append(issue.Attachments, att.Filename)
 		}
 	}
 
+	// Set components as a dedicated field or as prefixed labels
+	componentsLabels := c.componentsLabels && !c.namespacedTags
+	if componentsLabels && len(jira.Fields.Components) > 0 {
+		// Copy so appending never writes into the Jira issue's label slice
+		issue.Labels = append([]string(nil), issue.Labels...)
+	}
+	for _, comp := range jira.Fields.Components {
+		if comp == nil || comp.Name == "" {
+			continue
+		}
+		if componentsLabels {
+			issue.Labels = append(issue.Labels, "component:"+comp.Name)
+		} else {
+			issue.Components = append(issue.Components, comp.Name)
+		}
+	}
+
+	// Set due date; it is date-only, unlike the other timestamps
+	if jira.Fields.DueDate != "" {
+		if dueDate, err := time.Parse(jiraDateLayout, jira.Fields.DueDate); err == nil {
+			issue.DueDate = dueDate
+		}
+	}
+
+	// Set classification and environment; Cloud sends environment as ADF
+	if jira.Fields.Security != nil {
+		issue.SecurityLevel = jira.Fields.Security.Name
+	}
+	issue.Environment = c.renderText(jira.Fields.Environment)
+
+	// Set time tracking totals
+	if tt := jira.Fields.TimeTracking; tt != nil {
+		issue.OriginalEstimate = time.Duration(tt.OriginalEstimateSeconds) * time.Second
+		issue.RemainingEstimate = time.Duration(tt.RemainingEstimateSeconds) * time.Second
+		issue.TimeSpent = time.Duration(tt.TimeSpentSeconds) * time.Second
+	}
+
+	// Set vote count
+	if jira.Fields.Votes != nil {
+		issue.VoteCount = jira.Fields.Votes.Votes
+	}
+
+	// Set release versions
+	issue.FixVersions = versionNames(jira.Fields.FixVersions)
+	issue.AffectsVersions = versionNames(jira.Fields.Versions)
+	if c.fixVersionMilestone {
+		issue.Milestone = milestoneVersion(jira.Fields.FixVersions)
+	}
+
+	// Tag backlog issues, which otherwise look like ready-to-start ones
+	if backlog && !slices.Contains(issue.Labels, c.backlogLabel) {
+		// Copy so appending never writes into the Jira issue's label slice
+		issue.Labels = append(slices.Clip(issue.Labels), c.backlogLabel)
+	}
+
+	// Flatten labels, components, and fix versions into namespaced tags
+	if c.namespacedTags {
+		issue.Labels = namespacedTags(issue.Labels, issue.Components, issue.FixVersions)
+	}
+
+	// Set epic name from the legacy custom field
+	if c.epicNameField != "" {
+		issue.EpicName = parseStringField(jira.Fields.Raw[c.epicNameField])
+	}
+
+	// Set backlog rank
+	if c.rankField != "" {
+		issue.Rank = parseStringField(jira.Fields.Raw[c.rankField])
+	}
+
+	// Set story points; missing or non-numeric values leave it at zero
+	if c.storyPointsField != "" {
+		issue.Estimate = parseFloatField(jira.Fields.Raw[c.storyPointsField])
+	}
+
 	return issue, nil
 }
 
+// namespacedTags prefixes each label, component, and fix version with its
+// origin, dropping duplicates.
+func namespacedTags(labels, components, versions []string) []string {
+	tags := make([]string, 0, len(labels)+len(components)+len(versions))
+	seen := make(map[string]bool, cap(tags))
+	add := func(namespace string, names []string) {
+		for _, name := range names {
+			tag := namespace + ":" + name
+			if name == "" || seen[tag] {
+				continue
+			}
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	add("label", labels)
+	add("component", components)
+	add("version", versions)
+	return tags
+}
+
+// transformLabels applies the configured LabelTransform, dropping rejected
+// and duplicate labels. Labels are returned unchanged if no transform is set.
+func (c *Converter) transformLabels(labels []string) []string {
+	if c.labelTransform == nil || len(labels) == 0 {
+		return labels
+	}
+	seen := make(map[string]bool, len(labels))
+	result := make([]string, 0, len(labels))
+	for _, label := range labels {
+		label, ok := c.labelTransform(label)
+		if !ok || seen[label] {
+			continue
+		}
+		seen[label] = true
+		result = append(result, label)
+	}
+	return result
+}
+
+// versionNames returns the names of the given versions, or nil if there are none.
+func versionNames(versions []*JiraVersion) []string {
+	var names []string
+	for _, v := range versions {
+		if v != nil && v.Name != "" {
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
+
+// milestoneVersion picks the fix version to use as a milestone: the first
+// unreleased version, or the first version if all have been released.
+func milestoneVersion(versions []*JiraVersion) string {
+	first := ""
+	for _, v := range versions {
+		if v == nil || v.Name == "" {
+			continue
+		}
+		if !v.Released {
+			return v.Name
+		}
+		if first == "" {
+			first = v.Name
+		}
+	}
+	return first
+}
+
+// prefixFor returns the ID prefix for a Jira key, looked up by its project
+// component in PrefixByProject and falling back to the default prefix.
+func (c *Converter) prefixFor(key string) string {
+	if i := strings.LastIndex(key, "-"); i > 0 {
+		if prefix, ok := c.projectPrefixes[key[:i]]; ok {
+			return prefix
+		}
+	}
+	return c.prefix
+}
+
+// renderText extracts plain text from a string or ADF field value,
+// normalizing it if NormalizeText is set.
+func (c *Converter) renderText(value any) string {
+	text := c.adf.extractText(value)
+	if c.normalizeText {
+		text = normalizeText(text)
+	}
+	return text
+}
+
+// textReplacer maps typographic characters to plain equivalents for normalizeText.
+var textReplacer = strings.NewReplacer(
+	"\u2018", "'", "\u2019", "'", "\u201A", "'", "\u201B", "'",
+	"\u201C", `"`, "\u201D", `"`, "\u201E", `"`, "\u201F", `"`,
+	"\u00A0", " ", "\u2007", " ", "\u202F", " ",
+	"\u200B", "", "\u200C", "", "\u200D", "", "\u2060", "", "\uFEFF", "",
+)
+
+// normalizeText NFC-normalizes s and replaces smart quotes, non-breaking
+// spaces, and zero-width characters with plain equivalents.
+func normalizeText(s string) string {
+	return norm.NFC.String(textReplacer.Replace(s))
+}
+
+// truncateRunes cuts s to at most limit runes, ending it with an ellipsis
+// (counted in the limit) if anything was removed. A limit of zero or less
+// leaves s unchanged.
+func truncateRunes(s string, limit int) string {
+	if limit <= 0 || utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:limit-1]) + "…"
+}
+
+// parentKey returns the Jira key of the issue's parent: the legacy Epic Link
+// custom field if configured and set, otherwise the parent field.
+func (c *Converter) parentKey(jira *JiraIssue) string {
+	if c.epicLinkField != "" {
+		if key := parseStringField(jira.Fields.Raw[c.epicLinkField]); key != "" {
+			return key
+		}
+	}
+	if jira.Fields.Parent != nil {
+		return jira.Fields.Parent.Key
+	}
+	return ""
+}
+
+// parentIsEpic reports whether the issue's parent is an epic: always for the
+// legacy Epic Link field, and otherwise when the parent's issue type maps to
+// epic or sits at the epic hierarchy level, as on team-managed projects.
+func (c *Converter) parentIsEpic(jira *JiraIssue) bool {
+	if c.epicLinkField != "" && parseStringField(jira.Fields.Raw[c.epicLinkField]) != "" {
+		return true
+	}
+	if jira.Fields.Parent == nil || jira.Fields.Parent.Fields.IssueType == nil {
+		return false
+	}
+	parentType := jira.Fields.Parent.Fields.IssueType
+	return parentType.HierarchyLevel == 1 || c.mapIssueType(parentType) == types.TypeEpic
+}
+
+// extractSprint returns the issue's current sprint, or nil if it has none.
+func (c *Converter) extractSprint(jira *JiraIssue) *JiraSprint {
+	if c.sprintFieldID != "" {
+		if raw, ok := jira.Fields.Raw[c.sprintFieldID]; ok {
+			if sprint := currentSprint(parseSprintField(raw)); sprint != nil {
+				return sprint
+			}
+		}
+	}
+	return jira.Fields.Sprint
+}
+
 // extractDependencies extracts bd dependencies from Jira issue links.
-func (c *Converter) extractDependencies(jira *JiraIssue) []*types.Dependency {
+// Links whose target was not imported are returned as unresolved references.
+func (c *Converter) extractDependencies(jira *JiraIssue) ([]*types.Dependency, []UnresolvedReference) {
 	var deps []*types.Dependency
+	var unresolved []UnresolvedReference
 	bdID := c.jiraKeyToBDID[jira.Key]
 
 	// Handle issue links
@@ -235,7 +963,7 @@ func (c *Converter) extractDependencies(jira *JiraIssue) []*types.Dependency {
 			continue
 		}
 
-		linkType := strings.ToLower(link.Type.Name)
+		isBlocking := c.blockingLinkTypes[strings.ToLower(link.Type.Name)]
 		var linkedKey string
 		var depType types.DependencyType
 
@@ -243,7 +971,7 @@ func (c *Converter) extractDependencies(jira *JiraIssue) []*types.Dependency {
 			linkedKey = link.InwardIssue.Key
 			// Inward means the other issue has this relationship TO us
 			// e.g., "is blocked by" means linked_key blocks us
-			if strings.Contains(linkType, "block") {
+			if isBlocking {
 				depType = types.DepBlocks
 			} else {
 				depType = types.DepRelated
@@ -252,28 +980,39 @@ func (c *Converter) extractDependencies(jira *JiraIssue) []*types.Dependency {
 			linkedKey = link.OutwardIssue.Key
 			// Outward means we have this relationship TO the other issue
 			// e.g., "blocks" means we block linked_key
-			if strings.Contains(linkType, "block") {
+			if isBlocking {
 				// Flip: if we block them, they depend on us (not stored as our dep)
 				continue
 			}
 			depType = types.DepRelated
 		}
 
+		if linkedKey == "" || c.isLeftOut(linkedKey) {
+			continue
+		}
+
 		// Only add if the linked issue was also imported
 		linkedBDID, exists := c.jiraKeyToBDID[linkedKey]
-		if exists && linkedKey != "" {
-			deps = append(deps, &types.Dependency{
-				IssueID:     bdID,
-				DependsOnID: linkedBDID,
-				Type:        depType,
-				CreatedAt:   time.Now(),
+		if !exists {
+			unresolved = append(unresolved, UnresolvedReference{
+				IssueKey:  jira.Key,
+				TargetKey: linkedKey,
+				LinkType:  link.Type.Name,
 			})
+			continue
 		}
+		deps = append(deps, &types.Dependency{
+			IssueID:     bdID,
+			DependsOnID: linkedBDID,
+			Type:        depType,
+			CreatedAt:   time.Now(),
+		})
 	}
 
-	// Handle parent (epic link)
-	if jira.Fields.Parent != nil {
-		parentBDID, exists := c.jiraKeyToBDID[jira.Fields.Parent.Key]
+	// Handle parent (epic link), unless the parent was left out
+	parentKey := c.parentKey(jira)
+	if parentKey != "" && !c.isLeftOut(parentKey) {
+		parentBDID, exists := c.jiraKeyToBDID[parentKey]
 		if exists {
 			deps = append(deps, &types.Dependency{
 				IssueID:     bdID,
@@ -281,50 +1020,201 @@ func (c *Converter) extractDependencies(jira *JiraIssue) []*types.Dependency {
 				Type:        types.DepParentChild,
 				CreatedAt:   time.Now(),
 			})
+		} else {
+			unresolved = append(unresolved, UnresolvedReference{
+				IssueKey:  jira.Key,
+				TargetKey: parentKey,
+				LinkType:  ParentLinkType,
+			})
 		}
 	}
 
-	return deps
+	return deps, unresolved
+}
+
+// isBacklog reports whether status is one of the configured backlog statuses.
+func (c *Converter) isBacklog(status *JiraStatus) bool {
+	return status != nil && c.backlogStatuses[strings.ToLower(status.Name)]
 }
 
 // mapStatus maps a Jira status to a bd status.
+// Configured overrides take precedence over DefaultStatusMapping.
 func (c *Converter) mapStatus(status *JiraStatus) types.Status {
+	bdStatus, _ := c.resolveStatus(status)
+	return bdStatus
+}
+
+// resolveStatus maps a Jira status and reports whether a mapping matched.
+// If none matched, the fallback StatusOpen is returned with false.
+func (c *Converter) resolveStatus(status *JiraStatus) (types.Status, bool) {
 	if status == nil {
-		return types.StatusOpen
+		return types.StatusOpen, false
 	}
 	name := strings.ToLower(status.Name)
 	if bdStatus, ok := c.statusMap[name]; ok {
-		return bdStatus
+		return bdStatus, true
+	}
+	if bdStatus, ok := DefaultStatusMapping[name]; ok {
+		return bdStatus, true
 	}
-	return types.StatusOpen
+	return types.StatusOpen, false
 }
 
 // mapIssueType maps a Jira issue type to a bd issue type.
 func (c *Converter) mapIssueType(issueType *JiraIssueType) types.IssueType {
+	bdType, _ := c.resolveIssueType(issueType)
+	return bdType
+}
+
+// resolveIssueType maps a Jira issue type and reports whether a mapping
+// matched. Subtask types with no exact mapping are tried against the
+// substring rules. If none matched, the fallback TypeTask is returned with false.
+func (c *Converter) resolveIssueType(issueType *JiraIssueType) (types.IssueType, bool) {
 	if issueType == nil {
-		return types.TypeTask
+		return types.TypeTask, false
 	}
 	name := strings.ToLower(issueType.Name)
 	if bdType, ok := c.typeMap[name]; ok {
-		return bdType
+		return bdType, true
+	}
+	if issueType.Subtask {
+		for _, rule := range c.subtaskTypeRules {
+			if rule.Substring != "" && strings.Contains(name, strings.ToLower(rule.Substring)) {
+				return rule.Type, true
+			}
+		}
 	}
-	return types.TypeTask
+	return types.TypeTask, false
 }
 
-// mapPriority maps a Jira priority to a bd priority.
+// mapPriority maps a Jira priority to a bd priority. PriorityByID is
+// consulted first, then configured name overrides, then DefaultPriorityMapping.
 func (c *Converter) mapPriority(priority *JiraPriority) int {
+	bdPriority, _ := c.resolvePriority(priority)
+	return bdPriority
+}
+
+// resolvePriority maps a Jira priority and reports whether a mapping matched.
+// If none matched, the fallback 2 (medium) is returned with false.
+func (c *Converter) resolvePriority(priority *JiraPriority) (int, bool) {
 	if priority == nil {
-		return 2 // Default medium
+		return 2, false // Default medium
+	}
+	if bdPriority, ok := c.priorityByID[priority.ID]; ok && priority.ID != "" {
+		return bdPriority, true
 	}
 	name := strings.ToLower(priority.Name)
 	if bdPriority, ok := c.priorityMap[name]; ok {
-		return bdPriority
+		return bdPriority, true
+	}
+	if bdPriority, ok := DefaultPriorityMapping[name]; ok {
+		return bdPriority, true
+	}
+	return 2, false
+}
+
+// parseFloatField decodes a numeric custom field value, accepting either a
+// JSON number or a numeric string. Returns zero if the value is missing or
+// not a number.
+func parseFloatField(raw json.RawMessage) float64 {
+	if len(raw) == 0 {
+		return 0
+	}
+	var f float64
+	if err := json.Unmarshal(raw, &f); err == nil {
+		return f
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if f, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+			return f
+		}
 	}
-	return 2
+	return 0
 }
 
+// parseStringField decodes a raw custom field holding a string. Missing,
+// null, or non-string values yield an empty string.
+func parseStringField(raw json.RawMessage) string {
+	var s string
+	if len(raw) == 0 || json.Unmarshal(raw, &s) != nil {
+		return ""
+	}
+	return strings.TrimSpace(s)
+}
+
+// isFlagged reports whether a Flagged field value is set. Jira sends null
+// or an empty array when unflagged and an array of options (e.g.
+// [{"value": "Impediment"}]) when flagged.
+func isFlagged(raw json.RawMessage) bool {
+	var value any
+	if len(raw) == 0 || json.Unmarshal(raw, &value) != nil {
+		return false
+	}
+	switch v := value.(type) {
+	case nil:
+		return false
+	case []any:
+		return len(v) > 0
+	case string:
+		return v != ""
+	case bool:
+		return v
+	}
+	return true
+}
+
+// parseTeamField extracts a team from an Advanced Roadmaps Team field, which
+// is an object such as {"id": "36885b3c-...", "name": "Platform"} on Cloud
+// and a plain team ID on Server/DC. The name is preferred, then the title,
+// then the ID.
+func parseTeamField(raw json.RawMessage) string {
+	var value any
+	if len(raw) == 0 || json.Unmarshal(raw, &value) != nil {
+		return ""
+	}
+	if team, ok := value.(map[string]any); ok {
+		for _, key := range []string{"name", "title", "id"} {
+			if v := teamScalar(team[key]); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+	return teamScalar(value)
+}
+
+// teamScalar formats a string or numeric team value.
+func teamScalar(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return ""
+}
+
+// lowercaseKeys returns a copy of m with all keys lowercased, so that
+// user-supplied mappings can be matched case-insensitively.
+func lowercaseKeys[V any](m map[string]V) map[string]V {
+	result := make(map[string]V, len(m))
+	for k, v := range m {
+		result[strings.ToLower(k)] = v
+	}
+	return result
+}
+
+// jiraDateLayout is the layout of Jira date-only fields such as duedate.
+const jiraDateLayout = "2006-01-02"
+
+// tzOffsetNoColon matches a trailing "+0000"-style offset without a colon.
+var tzOffsetNoColon = regexp.MustCompile(`([+-]\d{2})(\d{2})$`)
+
 // parseJiraTimestamp parses a Jira timestamp string.
 // Jira uses ISO 8601 with timezone: 2024-01-15T10:30:00.000+0000 or 2024-01-15T10:30:00.000Z
+// The result keeps the offset Jira reported rather than being normalized to
+// UTC or the local zone.
 func parseJiraTimestamp(ts string) (time.Time, error) {
 	if ts == "" {
 		return time.Time{}, fmt.Errorf("empty timestamp")
@@ -336,30 +1226,33 @@ func parseJiraTimestamp(ts string) (time.Time, error) {
 	}
 
 	// Handle +0000 format (no colon in timezone)
-	re := regexp.MustCompile(`([+-]\d{2})(\d{2})$`)
-	ts = re.ReplaceAllString(ts, "$1:$2")
+	ts = tzOffsetNoColon.ReplaceAllString(ts, "$1:$2")
 
-	// Try parsing with fractional seconds
-	t, err := time.Parse("2006-01-02T15:04:05.000-07:00", ts)
-	if err == nil {
-		return t, nil
+	layouts := []string{
+		"2006-01-02T15:04:05.000-07:00",       // With milliseconds
+		"2006-01-02T15:04:05-07:00",           // Without fractional seconds
+		"2006-01-02T15:04:05.999999999-07:00", // Other fractional precision
 	}
-
-	// Try without fractional seconds
-	t, err = time.Parse("2006-01-02T15:04:05-07:00", ts)
-	if err == nil {
-		return t, nil
-	}
-
-	// Try with different fractional second precision
-	t, err = time.Parse("2006-01-02T15:04:05.999999999-07:00", ts)
-	if err == nil {
-		return t, nil
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, ts); err == nil {
+			return withFixedOffset(t), nil
+		}
 	}
 
 	return time.Time{}, fmt.Errorf("unable to parse timestamp: %s", ts)
 }
 
+// withFixedOffset returns t in a fixed zone with its current offset.
+// time.Parse returns time.Local when the offset happens to match the local
+// zone, which would make results depend on the host's time zone.
+func withFixedOffset(t time.Time) time.Time {
+	_, offset := t.Zone()
+	if offset == 0 {
+		return t.UTC()
+	}
+	return t.In(time.FixedZone("", offset))
+}
+
 // GetJiraKeyToBDIDMap returns the mapping from Jira keys to bd IDs.
 // Useful for post-conversion operations.
 func (c *Converter) GetJiraKeyToBDIDMap() map[string]string {
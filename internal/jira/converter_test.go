@@ -1,8 +1,17 @@
 package jira
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -31,6 +40,47 @@ func TestParseJiraTimestamp(t *testing.T) {
 	}
 }
 
+func TestConverter_PreservesTimestampOffset(t *testing.T) {
+	// Parsing must not depend on the host zone, even one with the same offset
+	origLocal := time.Local
+	time.Local = time.FixedZone("EST", -5*60*60)
+	defer func() { time.Local = origLocal }()
+
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net"})
+	issue, err := converter.ConvertOne(&JiraIssue{
+		Key: "PROJ-1",
+		Fields: JiraIssueFields{
+			Status:         &JiraStatus{Name: "Done"},
+			Created:        "2024-01-15T10:30:00.000-0500",
+			Updated:        "2024-01-16T08:00:00.000+0530",
+			ResolutionDate: "2024-01-17T09:00:00.000Z",
+		},
+	})
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		got        time.Time
+		wantOffset int
+		wantHour   int
+	}{
+		{"CreatedAt", issue.CreatedAt, -5 * 60 * 60, 10},
+		{"UpdatedAt", issue.UpdatedAt, 5*60*60 + 30*60, 8},
+		{"ClosedAt", *issue.ClosedAt, 0, 9},
+	}
+	for _, tt := range tests {
+		name, offset := tt.got.Zone()
+		if offset != tt.wantOffset || tt.got.Hour() != tt.wantHour {
+			t.Errorf("%s = %v, want hour %d at offset %d", tt.name, tt.got, tt.wantHour, tt.wantOffset)
+		}
+		if tt.got.Location() == time.Local || name == "EST" {
+			t.Errorf("%s is in the host zone (%s), want a fixed offset", tt.name, name)
+		}
+	}
+}
+
 func TestConverter_MapStatus(t *testing.T) {
 	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net"})
 
@@ -62,6 +112,36 @@ func TestConverter_MapStatus(t *testing.T) {
 	}
 }
 
+func TestConverter_MapStatusOverrides(t *testing.T) {
+	converter := NewConverter(ConverterConfig{
+		JiraURL: "https://test.atlassian.net",
+		StatusMap: map[string]types.Status{
+			"Ready for QA": types.StatusInProgress,
+			"done":         types.StatusBlocked,
+		},
+	})
+
+	tests := []struct {
+		jiraStatus string
+		want       types.Status
+	}{
+		{"Ready for QA", types.StatusInProgress},
+		{"ready for qa", types.StatusInProgress},
+		{"Done", types.StatusBlocked},         // Override wins over default
+		{"Closed", types.StatusClosed},        // Falls back to default
+		{"Awaiting Deploy", types.StatusOpen}, // Unknown still defaults to open
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.jiraStatus, func(t *testing.T) {
+			got := converter.mapStatus(&JiraStatus{Name: tt.jiraStatus})
+			if got != tt.want {
+				t.Errorf("mapStatus(%q) = %v, want %v", tt.jiraStatus, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestConverter_MapIssueType(t *testing.T) {
 	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net"})
 
@@ -122,6 +202,73 @@ func TestConverter_MapPriority(t *testing.T) {
 	}
 }
 
+func TestConverter_MapPriorityOverrides(t *testing.T) {
+	converter := NewConverter(ConverterConfig{
+		JiraURL: "https://test.atlassian.net",
+		PriorityMap: map[string]int{
+			"P0": 0,
+			"P1": 1,
+			"P2": 2,
+			"P3": 3,
+			"p4": 4,
+		},
+	})
+
+	tests := []struct {
+		jiraPriority string
+		want         int
+	}{
+		{"P0", 0},
+		{"p1", 1},
+		{"P2", 2},
+		{"P3", 3},
+		{"P4", 4},
+		{"Highest", 0}, // Falls back to default
+		{"P9", 2},      // Unknown still defaults to medium
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.jiraPriority, func(t *testing.T) {
+			got := converter.mapPriority(&JiraPriority{Name: tt.jiraPriority})
+			if got != tt.want {
+				t.Errorf("mapPriority(%q) = %v, want %v", tt.jiraPriority, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConverter_PriorityByID(t *testing.T) {
+	converter := NewConverter(ConverterConfig{
+		JiraURL:      "https://test.atlassian.net",
+		PriorityByID: map[string]int{"1": 0, "2": 1, "3": 2, "4": 3, "5": 4},
+		PriorityMap:  map[string]int{"hoch": 3}, // IDs win over names
+	})
+
+	tests := []struct {
+		priority string
+		want     int
+	}{
+		{`{"id": "1", "name": "Höchste"}`, 0},
+		{`{"id": "2", "name": "Hoch"}`, 1},
+		{`{"id": "4", "name": "Niedrig"}`, 3},
+		{`{"id": "5", "name": "Niedrigste"}`, 4},
+		{`{"id": "10000", "name": "High"}`, 1},     // Unknown ID falls back to the name
+		{`{"id": "10001", "name": "Dringend"}`, 2}, // Neither matches
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.priority, func(t *testing.T) {
+			var priority JiraPriority
+			if err := json.Unmarshal([]byte(tt.priority), &priority); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if got := converter.mapPriority(&priority); got != tt.want {
+				t.Errorf("mapPriority(%s) = %d, want %d", tt.priority, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestConverter_Convert(t *testing.T) {
 	converter := NewConverter(ConverterConfig{
 		JiraURL: "https://test.atlassian.net",
@@ -217,73 +364,559 @@ func TestConverter_ConvertWithIDGenerator(t *testing.T) {
 	}
 }
 
-func TestExtractKeyFromURL(t *testing.T) {
+func TestConverter_IDFromKey(t *testing.T) {
+	newIssues := func(keys ...string) []*JiraIssue {
+		issues := make([]*JiraIssue, len(keys))
+		for i, key := range keys {
+			issues[i] = &JiraIssue{Key: key, Fields: JiraIssueFields{Summary: "Issue " + key}}
+		}
+		return issues
+	}
+	cfg := ConverterConfig{JiraURL: "https://test.atlassian.net", Prefix: "test", IDFromKey: true}
+
+	first, err := NewConverter(cfg).Convert(newIssues("PROJ-123", "PROJ-7"))
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	// A later run sees the issues in a different order
+	second, err := NewConverter(cfg).Convert(newIssues("PROJ-7", "PROJ-123"))
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	if first[0].ID != "test-PROJ-123" || first[1].ID != "test-PROJ-7" {
+		t.Errorf("IDs = %q, %q; want test-PROJ-123, test-PROJ-7", first[0].ID, first[1].ID)
+	}
+	if first[0].ID != second[1].ID || first[1].ID != second[0].ID {
+		t.Errorf("IDs changed across runs: %q, %q then %q, %q", first[0].ID, first[1].ID, second[1].ID, second[0].ID)
+	}
+}
+
+func TestConverter_PrefixByProject(t *testing.T) {
+	converter := NewConverter(ConverterConfig{
+		JiraURL:         "https://test.atlassian.net",
+		Prefix:          "bd",
+		PrefixByProject: map[string]string{"FRONT": "fe", "BACK": "be"},
+	})
+
+	issues, err := converter.Convert([]*JiraIssue{
+		{Key: "FRONT-1", Fields: JiraIssueFields{Summary: "Button"}},
+		{Key: "BACK-1", Fields: JiraIssueFields{Summary: "Endpoint"}},
+		{Key: "OPS-1", Fields: JiraIssueFields{Summary: "Deploy"}},
+	})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	want := []string{"fe-1", "be-2", "bd-3"}
+	for i, issue := range issues {
+		if issue.ID != want[i] {
+			t.Errorf("issues[%d].ID = %q, want %q", i, issue.ID, want[i])
+		}
+	}
+
+	keyed := NewConverter(ConverterConfig{
+		JiraURL:         "https://test.atlassian.net",
+		PrefixByProject: map[string]string{"FRONT": "fe"},
+		IDFromKey:       true,
+	})
+	issue, err := keyed.ConvertOne(&JiraIssue{Key: "FRONT-7", Fields: JiraIssueFields{Summary: "Key"}})
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+	if issue.ID != "fe-FRONT-7" {
+		t.Errorf("ID = %q, want fe-FRONT-7", issue.ID)
+	}
+}
+
+func TestConverter_ConvertOneMatchesConvert(t *testing.T) {
+	newIssue := func() *JiraIssue {
+		return &JiraIssue{
+			Key: "PROJ-42",
+			Fields: JiraIssueFields{
+				Summary:        "Single issue",
+				Description:    "Details",
+				Status:         &JiraStatus{Name: "Done"},
+				Priority:       &JiraPriority{Name: "Low"},
+				IssueType:      &JiraIssueType{Name: "Story"},
+				Created:        "2024-01-15T10:30:00.000+0000",
+				Updated:        "2024-01-16T11:00:00.000+0000",
+				ResolutionDate: "2024-01-17T09:00:00.000+0000",
+				Reporter:       &JiraUser{DisplayName: "John Doe"},
+				Labels:         []string{"backend"},
+			},
+		}
+	}
+	cfg := ConverterConfig{JiraURL: "https://test.atlassian.net", Prefix: "test"}
+
+	one, err := NewConverter(cfg).ConvertOne(newIssue())
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+
+	batch, err := NewConverter(cfg).Convert([]*JiraIssue{newIssue()})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(one, batch[0]) {
+		t.Errorf("ConvertOne() = %+v, Convert()[0] = %+v", one, batch[0])
+	}
+}
+
+func TestConverter_ConvertOneRecordsMapping(t *testing.T) {
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net", Prefix: "test"})
+
+	for _, key := range []string{"PROJ-1", "PROJ-2"} {
+		if _, err := converter.ConvertOne(&JiraIssue{Key: key}); err != nil {
+			t.Fatalf("ConvertOne(%s) error = %v", key, err)
+		}
+	}
+
+	mapping := converter.GetJiraKeyToBDIDMap()
+	if mapping["PROJ-1"] != "test-1" || mapping["PROJ-2"] != "test-2" {
+		t.Errorf("GetJiraKeyToBDIDMap() = %v, want PROJ-1=test-1 PROJ-2=test-2", mapping)
+	}
+}
+
+func TestConverter_Resolution(t *testing.T) {
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net", Prefix: "test"})
+
+	jiraIssues := []*JiraIssue{
+		{
+			Key: "PROJ-1",
+			Fields: JiraIssueFields{
+				Summary:        "Won't do",
+				Status:         &JiraStatus{Name: "Closed"},
+				Resolution:     &JiraResolution{Name: "Won't Do"},
+				ResolutionDate: "2024-02-01T08:00:00.000+0000",
+			},
+		},
+		{
+			Key: "PROJ-2",
+			Fields: JiraIssueFields{
+				Summary: "Closed without resolution",
+				Status:  &JiraStatus{Name: "Done"},
+			},
+		},
+		{
+			Key: "PROJ-3",
+			Fields: JiraIssueFields{
+				Summary:    "Still open",
+				Status:     &JiraStatus{Name: "In Progress"},
+				Resolution: &JiraResolution{Name: "Fixed"},
+			},
+		},
+	}
+
+	issues, err := converter.Convert(jiraIssues)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	wontDo := issues[0]
+	if wontDo.CloseReason != "Won't Do" {
+		t.Errorf("CloseReason = %q, want %q", wontDo.CloseReason, "Won't Do")
+	}
+	wantClosedAt := time.Date(2024, 2, 1, 8, 0, 0, 0, time.UTC)
+	if wontDo.ClosedAt == nil || !wontDo.ClosedAt.Equal(wantClosedAt) {
+		t.Errorf("ClosedAt = %v, want %v", wontDo.ClosedAt, wantClosedAt)
+	}
+
+	noResolution := issues[1]
+	if noResolution.Status != types.StatusClosed {
+		t.Errorf("Status = %v, want %v", noResolution.Status, types.StatusClosed)
+	}
+	if noResolution.CloseReason != "" {
+		t.Errorf("CloseReason = %q, want empty", noResolution.CloseReason)
+	}
+	if noResolution.ClosedAt != nil {
+		t.Errorf("ClosedAt = %v, want nil", noResolution.ClosedAt)
+	}
+
+	if issues[2].CloseReason != "" {
+		t.Errorf("open issue CloseReason = %q, want empty", issues[2].CloseReason)
+	}
+}
+
+func TestConverter_SourceStatus(t *testing.T) {
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net"})
+
+	for _, name := range []string{"Resolved", "Closed"} {
+		issue, err := converter.ConvertOne(&JiraIssue{
+			Key:    "PROJ-1",
+			Fields: JiraIssueFields{Summary: "Fixed", Status: &JiraStatus{Name: name}},
+		})
+		if err != nil {
+			t.Fatalf("ConvertOne() error = %v", err)
+		}
+		if issue.Status != types.StatusClosed {
+			t.Errorf("%s: Status = %q, want closed", name, issue.Status)
+		}
+		if issue.SourceStatus != name {
+			t.Errorf("SourceStatus = %q, want %q", issue.SourceStatus, name)
+		}
+	}
+}
+
+func TestConverter_FlaggedField(t *testing.T) {
+	issueJSON := func(status, flagged string) *JiraIssue {
+		var jira JiraIssue
+		raw := fmt.Sprintf(`{"key": "PROJ-1", "fields": {"summary": "Flag", "status": {"name": %q}, "customfield_10021": %s}}`, status, flagged)
+		if err := json.Unmarshal([]byte(raw), &jira); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		return &jira
+	}
+	const impediment = `[{"id": "10019", "value": "Impediment"}]`
+
 	tests := []struct {
-		input string
-		want  string
+		name           string
+		status         string
+		flagged        string
+		overrideClosed bool
+		wantStatus     types.Status
+		wantFlagged    bool
 	}{
-		{"https://company.atlassian.net/browse/PROJ-123", "PROJ-123"},
-		{"https://jira.company.com/browse/ABC-456", "ABC-456"},
-		{"https://test.atlassian.net/browse/TEST-1", "TEST-1"},
-		{"https://example.com/not-a-jira-url", ""},
-		{"", ""},
+		{"flagged in progress", "In Progress", impediment, false, types.StatusBlocked, true},
+		{"unflagged null", "In Progress", "null", false, types.StatusInProgress, false},
+		{"unflagged empty", "To Do", "[]", false, types.StatusOpen, false},
+		{"flagged closed keeps status", "Done", impediment, false, types.StatusClosed, true},
+		{"flagged closed overridden", "Done", impediment, true, types.StatusBlocked, true},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			got := ExtractKeyFromURL(tt.input)
-			if got != tt.want {
-				t.Errorf("ExtractKeyFromURL(%q) = %q, want %q", tt.input, got, tt.want)
+		t.Run(tt.name, func(t *testing.T) {
+			converter := NewConverter(ConverterConfig{
+				JiraURL:                "https://test.atlassian.net",
+				FlaggedFieldID:         "customfield_10021",
+				FlaggedOverridesClosed: tt.overrideClosed,
+			})
+			issue, err := converter.ConvertOne(issueJSON(tt.status, tt.flagged))
+			if err != nil {
+				t.Fatalf("ConvertOne() error = %v", err)
+			}
+			if issue.Status != tt.wantStatus || issue.Flagged != tt.wantFlagged {
+				t.Errorf("Status = %q, Flagged = %v; want %q, %v", issue.Status, issue.Flagged, tt.wantStatus, tt.wantFlagged)
 			}
 		})
 	}
 }
 
-func TestExtractTextFromADF(t *testing.T) {
-	// Simple ADF document
-	doc := map[string]any{
-		"type":    "doc",
-		"version": 1,
-		"content": []any{
-			map[string]any{
-				"type": "paragraph",
-				"content": []any{
-					map[string]any{
-						"type": "text",
-						"text": "Hello ",
-					},
-					map[string]any{
-						"type": "text",
-						"text": "World",
-					},
-				},
-			},
-		},
+func TestConverter_BacklogStatuses(t *testing.T) {
+	newIssue := func(key, status string, labels ...string) *JiraIssue {
+		return &JiraIssue{Key: key, Fields: JiraIssueFields{
+			Summary: key,
+			Status:  &JiraStatus{Name: status},
+			Labels:  labels,
+		}}
 	}
+	backlog := newIssue("PROJ-1", "Backlog", "ui")
+	todo := newIssue("PROJ-2", "To Do", "ui")
 
-	result := extractTextFromADF(doc)
-	if result != "Hello World" {
-		t.Errorf("extractTextFromADF() = %q, want %q", result, "Hello World")
+	// Tagged by default, with the mapped status unchanged
+	converter := NewConverter(ConverterConfig{BacklogStatuses: []string{"backlog"}})
+	got, err := converter.ConvertOne(backlog)
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+	if got.Status != types.StatusOpen || !reflect.DeepEqual(got.Labels, []string{"ui", "backlog"}) {
+		t.Errorf("Backlog: Status = %q, Labels = %v; want open, [ui backlog]", got.Status, got.Labels)
+	}
+	if !reflect.DeepEqual(backlog.Fields.Labels, []string{"ui"}) {
+		t.Errorf("Jira labels modified: %v", backlog.Fields.Labels)
+	}
+	got, err = converter.ConvertOne(todo)
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+	if got.Status != types.StatusOpen || !reflect.DeepEqual(got.Labels, []string{"ui"}) {
+		t.Errorf("To Do: Status = %q, Labels = %v; want open, [ui]", got.Status, got.Labels)
 	}
-}
 
-func TestJiraIssueFields_GetDescription(t *testing.T) {
-	t.Run("string description", func(t *testing.T) {
-		fields := JiraIssueFields{Description: "Plain text description"}
-		if got := fields.GetDescription(); got != "Plain text description" {
-			t.Errorf("GetDescription() = %q, want %q", got, "Plain text description")
+	// A configured status and label split the two apart
+	converter = NewConverter(ConverterConfig{
+		BacklogStatuses: []string{"Backlog"},
+		BacklogStatus:   types.StatusDeferred,
+		BacklogLabel:    "icebox",
+	})
+	for _, tt := range []struct {
+		issue      *JiraIssue
+		wantStatus types.Status
+		wantLabels []string
+	}{
+		{backlog, types.StatusDeferred, []string{"ui", "icebox"}},
+		{todo, types.StatusOpen, []string{"ui"}},
+	} {
+		got, err := converter.ConvertOne(tt.issue)
+		if err != nil {
+			t.Fatalf("ConvertOne() error = %v", err)
 		}
+		if got.Status != tt.wantStatus || !reflect.DeepEqual(got.Labels, tt.wantLabels) {
+			t.Errorf("%s: Status = %q, Labels = %v; want %q, %v", tt.issue.Fields.Status.Name, got.Status, got.Labels, tt.wantStatus, tt.wantLabels)
+		}
+	}
+}
+
+func TestConverter_BacklogStatusesNamespaced(t *testing.T) {
+	converter := NewConverter(ConverterConfig{
+		BacklogStatuses: []string{"Backlog"},
+		NamespacedTags:  true,
 	})
 
-	t.Run("nil description", func(t *testing.T) {
-		fields := JiraIssueFields{Description: nil}
-		if got := fields.GetDescription(); got != "" {
-			t.Errorf("GetDescription() = %q, want empty", got)
+	tests := []struct {
+		name   string
+		labels []string
+		want   []string
+	}{
+		{"tagged", []string{"x"}, []string{"label:x", "label:backlog"}},
+		{"already labeled", []string{"backlog", "x"}, []string{"label:backlog", "label:x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue, err := converter.ConvertOne(&JiraIssue{Key: "PROJ-1", Fields: JiraIssueFields{
+				Status:     &JiraStatus{Name: "Backlog"},
+				Labels:     tt.labels,
+				Components: []*JiraComponent{{Name: "ui"}},
+			}})
+			if err != nil {
+				t.Fatalf("ConvertOne() error = %v", err)
+			}
+			want := append(tt.want, "component:ui")
+			if !reflect.DeepEqual(issue.Labels, want) {
+				t.Errorf("Labels = %v, want %v", issue.Labels, want)
+			}
+		})
+	}
+}
+
+func TestConverter_TeamField(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"object with name", `{"id": "36885b3c-1bf0-4f85-a357-c5b858c31de4", "name": "Platform", "isShared": true}`, "Platform"},
+		{"object with title only", `{"id": 42, "title": "Payments"}`, "Payments"},
+		{"object with id only", `{"id": 42}`, "42"},
+		{"plain string", `"Mobile"`, "Mobile"},
+		{"plain numeric id", `1007`, "1007"},
+		{"null", `null`, ""},
+	}
+
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net", TeamFieldID: "customfield_10001"})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var jira JiraIssue
+			raw := `{"key": "PROJ-1", "fields": {"summary": "Team", "customfield_10001": ` + tt.value + `}}`
+			if err := json.Unmarshal([]byte(raw), &jira); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			issue, err := converter.ConvertOne(&jira)
+			if err != nil {
+				t.Fatalf("ConvertOne() error = %v", err)
+			}
+			if issue.Team != tt.want {
+				t.Errorf("Team = %q, want %q", issue.Team, tt.want)
+			}
+		})
+	}
+
+	t.Run("absent", func(t *testing.T) {
+		issue, err := converter.ConvertOne(&JiraIssue{Key: "PROJ-2", Fields: JiraIssueFields{Summary: "No team"}})
+		if err != nil {
+			t.Fatalf("ConvertOne() error = %v", err)
+		}
+		if issue.Team != "" {
+			t.Errorf("Team = %q, want empty", issue.Team)
 		}
 	})
+}
 
-	t.Run("ADF description", func(t *testing.T) {
-		fields := JiraIssueFields{
+func TestConverter_StoryPoints(t *testing.T) {
+	payload := `[
+		{"key": "PROJ-1", "fields": {"summary": "Pointed", "customfield_10016": 5.0}},
+		{"key": "PROJ-2", "fields": {"summary": "String points", "customfield_10016": "3.5"}},
+		{"key": "PROJ-3", "fields": {"summary": "Unpointed", "customfield_10016": null}},
+		{"key": "PROJ-4", "fields": {"summary": "Garbage", "customfield_10016": {"value": "big"}}},
+		{"key": "PROJ-5", "fields": {"summary": "Missing"}}
+	]`
+	var jiraIssues []*JiraIssue
+	if err := json.Unmarshal([]byte(payload), &jiraIssues); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	converter := NewConverter(ConverterConfig{
+		JiraURL:            "https://test.atlassian.net",
+		StoryPointsFieldID: "customfield_10016",
+	})
+	issues, err := converter.Convert(jiraIssues)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	want := []float64{5.0, 3.5, 0, 0, 0}
+	for i, w := range want {
+		if issues[i].Estimate != w {
+			t.Errorf("%s Estimate = %v, want %v", jiraIssues[i].Key, issues[i].Estimate, w)
+		}
+	}
+}
+
+func TestConverter_ParentMapping(t *testing.T) {
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net", Prefix: "test"})
+
+	jiraIssues := []*JiraIssue{
+		{Key: "PROJ-1", Fields: JiraIssueFields{Summary: "Story", IssueType: &JiraIssueType{Name: "Story"}}},
+		{
+			Key: "PROJ-2",
+			Fields: JiraIssueFields{
+				Summary:   "Subtask with parent",
+				IssueType: &JiraIssueType{Name: "Sub-task", Subtask: true},
+				Parent:    &JiraParent{Key: "PROJ-1"},
+			},
+		},
+		{
+			Key: "PROJ-3",
+			Fields: JiraIssueFields{
+				Summary:   "Subtask with missing parent",
+				IssueType: &JiraIssueType{Name: "Sub-task", Subtask: true},
+				Parent:    &JiraParent{Key: "PROJ-99"},
+			},
+		},
+	}
+
+	result, err := converter.ConvertWithDependencies(jiraIssues)
+	if err != nil {
+		t.Fatalf("ConvertWithDependencies() error = %v", err)
+	}
+
+	present := result.Issues[1]
+	if present.ParentID != "test-1" {
+		t.Errorf("ParentID = %q, want test-1", present.ParentID)
+	}
+	if len(present.Dependencies) != 1 || present.Dependencies[0].Type != types.DepParentChild ||
+		present.Dependencies[0].DependsOnID != "test-1" {
+		t.Errorf("Dependencies = %+v, want parent-child on test-1", present.Dependencies)
+	}
+
+	absent := result.Issues[2]
+	if absent.ParentID != "" {
+		t.Errorf("ParentID = %q, want empty for missing parent", absent.ParentID)
+	}
+	if len(result.Unresolved) != 1 {
+		t.Fatalf("Unresolved count = %d, want 1", len(result.Unresolved))
+	}
+	want := UnresolvedReference{IssueKey: "PROJ-3", TargetKey: "PROJ-99", LinkType: ParentLinkType}
+	if result.Unresolved[0] != want {
+		t.Errorf("Unresolved[0] = %+v, want %+v", result.Unresolved[0], want)
+	}
+}
+
+func TestConverter_ParentIsEpic(t *testing.T) {
+	raw := `[
+		{"key": "PROJ-2", "fields": {"summary": "Story in epic",
+			"parent": {"key": "PROJ-1", "fields": {"summary": "Epic", "issuetype": {"name": "Epic", "hierarchyLevel": 1}}}}},
+		{"key": "PROJ-3", "fields": {"summary": "Subtask of story", "issuetype": {"name": "Subtask", "subtask": true},
+			"parent": {"key": "PROJ-2", "fields": {"summary": "Story in epic", "issuetype": {"name": "Story", "hierarchyLevel": 0}}}}},
+		{"key": "PROJ-4", "fields": {"summary": "Renamed epic level",
+			"parent": {"key": "PROJ-5", "fields": {"issuetype": {"name": "Feature Set", "hierarchyLevel": 1}}}}},
+		{"key": "PROJ-6", "fields": {"summary": "No parent"}}
+	]`
+	var jiraIssues []*JiraIssue
+	if err := json.Unmarshal([]byte(raw), &jiraIssues); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got := jiraIssues[0].Fields.Parent.Fields.IssueType.Name; got != "Epic" {
+		t.Fatalf("parent issue type = %q, want Epic", got)
+	}
+
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net"})
+	issues, err := converter.Convert(jiraIssues)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	want := []bool{true, false, true, false}
+	for i, issue := range issues {
+		if issue.ParentIsEpic != want[i] {
+			t.Errorf("%s ParentIsEpic = %v, want %v", jiraIssues[i].Key, issue.ParentIsEpic, want[i])
+		}
+	}
+}
+
+func TestExtractKeyFromURL(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"https://company.atlassian.net/browse/PROJ-123", "PROJ-123"},
+		{"https://jira.company.com/browse/ABC-456", "ABC-456"},
+		{"https://test.atlassian.net/browse/TEST-1", "TEST-1"},
+		{"https://jira.company.com/projects/PROJ/issues/PROJ-123", "PROJ-123"},
+		{"https://jira.company.com/jira/software/c/projects/PROJ/boards/1?selectedIssue=PROJ-77", "PROJ-77"},
+		{"https://jira.company.com/issues/?jql=project%3DPROJ&selectedIssue=ABC-9", "ABC-9"},
+		{"https://example.com/not-a-jira-url", ""},
+		{"https://example.com/projects/PROJ/boards/1?selected=PROJ-1", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := ExtractKeyFromURL(tt.input)
+			if got != tt.want {
+				t.Errorf("ExtractKeyFromURL(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractTextFromADF(t *testing.T) {
+	// Simple ADF document
+	doc := map[string]any{
+		"type":    "doc",
+		"version": 1,
+		"content": []any{
+			map[string]any{
+				"type": "paragraph",
+				"content": []any{
+					map[string]any{
+						"type": "text",
+						"text": "Hello ",
+					},
+					map[string]any{
+						"type": "text",
+						"text": "World",
+					},
+				},
+			},
+		},
+	}
+
+	result := (adfRenderer{}).render(doc)
+	if result != "Hello World" {
+		t.Errorf("render() = %q, want %q", result, "Hello World")
+	}
+}
+
+func TestJiraIssueFields_GetDescription(t *testing.T) {
+	t.Run("string description", func(t *testing.T) {
+		fields := JiraIssueFields{Description: "Plain text description"}
+		if got := fields.GetDescription(); got != "Plain text description" {
+			t.Errorf("GetDescription() = %q, want %q", got, "Plain text description")
+		}
+	})
+
+	t.Run("nil description", func(t *testing.T) {
+		fields := JiraIssueFields{Description: nil}
+		if got := fields.GetDescription(); got != "" {
+			t.Errorf("GetDescription() = %q, want empty", got)
+		}
+	})
+
+	t.Run("ADF description", func(t *testing.T) {
+		fields := JiraIssueFields{
 			Description: map[string]any{
 				"type": "doc",
 				"content": []any{
@@ -304,3 +937,910 @@ func TestJiraIssueFields_GetDescription(t *testing.T) {
 		}
 	})
 }
+
+func TestConverter_ConvertWithDependencies(t *testing.T) {
+	converter := NewConverter(ConverterConfig{
+		JiraURL:           "https://test.atlassian.net",
+		Prefix:            "test",
+		BlockingLinkTypes: []string{"Blocks", "Gates"},
+	})
+
+	jiraIssues := []*JiraIssue{
+		{
+			Key: "PROJ-1",
+			Fields: JiraIssueFields{
+				Summary: "Blocked issue",
+				IssueLinks: []*JiraIssueLink{
+					{Type: &JiraLinkType{Name: "Blocks"}, InwardIssue: &JiraLinkedIssue{Key: "PROJ-2"}},
+					{Type: &JiraLinkType{Name: "Gates"}, InwardIssue: &JiraLinkedIssue{Key: "PROJ-3"}},
+					{Type: &JiraLinkType{Name: "Blocks"}, InwardIssue: &JiraLinkedIssue{Key: "OTHER-9"}},
+				},
+			},
+		},
+		{
+			Key: "PROJ-2",
+			Fields: JiraIssueFields{
+				Summary: "Blocker",
+				IssueLinks: []*JiraIssueLink{
+					{Type: &JiraLinkType{Name: "Blocks"}, OutwardIssue: &JiraLinkedIssue{Key: "PROJ-1"}},
+				},
+			},
+		},
+		{
+			Key: "PROJ-3",
+			Fields: JiraIssueFields{
+				Summary: "Gate",
+				IssueLinks: []*JiraIssueLink{
+					{Type: &JiraLinkType{Name: "Relates"}, OutwardIssue: &JiraLinkedIssue{Key: "PROJ-2"}},
+				},
+			},
+		},
+	}
+
+	result, err := converter.ConvertWithDependencies(jiraIssues)
+	if err != nil {
+		t.Fatalf("ConvertWithDependencies() error = %v", err)
+	}
+
+	if len(result.Issues) != 3 {
+		t.Fatalf("Issues count = %d, want 3", len(result.Issues))
+	}
+
+	want := []struct {
+		from, to string
+		depType  types.DependencyType
+	}{
+		{"test-1", "test-2", types.DepBlocks},
+		{"test-1", "test-3", types.DepBlocks},
+		{"test-3", "test-2", types.DepRelated},
+	}
+	if len(result.Dependencies) != len(want) {
+		t.Fatalf("Dependencies count = %d, want %d", len(result.Dependencies), len(want))
+	}
+	for i, w := range want {
+		dep := result.Dependencies[i]
+		if dep.IssueID != w.from || dep.DependsOnID != w.to || dep.Type != w.depType {
+			t.Errorf("Dependencies[%d] = %s -> %s (%s), want %s -> %s (%s)",
+				i, dep.IssueID, dep.DependsOnID, dep.Type, w.from, w.to, w.depType)
+		}
+	}
+
+	if len(result.Issues[0].Dependencies) != 2 {
+		t.Errorf("PROJ-1 Dependencies count = %d, want 2", len(result.Issues[0].Dependencies))
+	}
+
+	if len(result.Unresolved) != 1 {
+		t.Fatalf("Unresolved count = %d, want 1", len(result.Unresolved))
+	}
+	ref := result.Unresolved[0]
+	if ref.IssueKey != "PROJ-1" || ref.TargetKey != "OTHER-9" || ref.LinkType != "Blocks" {
+		t.Errorf("Unresolved[0] = %+v, want PROJ-1 -> OTHER-9 (Blocks)", ref)
+	}
+}
+
+func TestConverter_SkipIssueTypes(t *testing.T) {
+	converter := NewConverter(ConverterConfig{
+		JiraURL:        "https://test.atlassian.net",
+		Prefix:         "test",
+		SkipIssueTypes: []string{"test", "Test Execution"},
+	})
+
+	jiraIssues := []*JiraIssue{
+		{Key: "PROJ-1", Fields: JiraIssueFields{
+			Summary:   "Login fails",
+			IssueType: &JiraIssueType{Name: "Bug"},
+			IssueLinks: []*JiraIssueLink{
+				{Type: &JiraLinkType{Name: "Blocks"}, InwardIssue: &JiraLinkedIssue{Key: "PROJ-2"}},
+				{Type: &JiraLinkType{Name: "Relates"}, OutwardIssue: &JiraLinkedIssue{Key: "PROJ-4"}},
+			},
+		}},
+		{Key: "PROJ-2", Fields: JiraIssueFields{Summary: "Login test", IssueType: &JiraIssueType{Name: "Test"}}},
+		{Key: "PROJ-3", Fields: JiraIssueFields{Summary: "Nightly run", IssueType: &JiraIssueType{Name: "TEST EXECUTION"}}},
+		{Key: "PROJ-4", Fields: JiraIssueFields{Summary: "Session handling", IssueType: &JiraIssueType{Name: "Story"}}},
+		{Key: "PROJ-5", Fields: JiraIssueFields{
+			Summary:   "Check step",
+			IssueType: &JiraIssueType{Name: "Sub-task", Subtask: true},
+			Parent:    &JiraParent{Key: "PROJ-3"},
+		}},
+	}
+
+	result, err := converter.ConvertWithDependencies(jiraIssues)
+	if err != nil {
+		t.Fatalf("ConvertWithDependencies() error = %v", err)
+	}
+
+	var titles []string
+	for _, issue := range result.Issues {
+		titles = append(titles, issue.Title)
+	}
+	if want := []string{"Login fails", "Session handling", "Check step"}; !reflect.DeepEqual(titles, want) {
+		t.Fatalf("titles = %v, want %v", titles, want)
+	}
+
+	// The link to the kept story survives; links into skipped issues vanish
+	if len(result.Dependencies) != 1 || result.Dependencies[0].DependsOnID != result.Issues[1].ID {
+		t.Errorf("Dependencies = %v, want only PROJ-1 -> PROJ-4", result.Dependencies)
+	}
+	if result.Issues[2].ParentID != "" {
+		t.Errorf("subtask ParentID = %q, want empty", result.Issues[2].ParentID)
+	}
+	if len(result.Unresolved) != 0 {
+		t.Errorf("Unresolved = %v, want none", result.Unresolved)
+	}
+}
+
+func TestConverter_SkipIssueTypesEveryEntryPoint(t *testing.T) {
+	jiraIssues := []*JiraIssue{
+		{Key: "PROJ-1", Fields: JiraIssueFields{Summary: "Bug", IssueType: &JiraIssueType{Name: "Bug"}}},
+		{Key: "PROJ-2", Fields: JiraIssueFields{Summary: "Test", IssueType: &JiraIssueType{Name: "Test"}}},
+		{Key: "PROJ-3", Fields: JiraIssueFields{Summary: "Story", IssueType: &JiraIssueType{Name: "Story"}}},
+		{Key: "PROJ-4", Fields: JiraIssueFields{Summary: "Run", IssueType: &JiraIssueType{Name: "Test Execution"}}},
+	}
+	stream := func(c *Converter, issues []*JiraIssue) ([]*types.Issue, error) {
+		var buf bytes.Buffer
+		if err := c.ConvertStream(context.Background(), issues, &buf); err != nil {
+			return nil, err
+		}
+		var result []*types.Issue
+		dec := json.NewDecoder(&buf)
+		for dec.More() {
+			var issue types.Issue
+			if err := dec.Decode(&issue); err != nil {
+				return nil, err
+			}
+			result = append(result, &issue)
+		}
+		return result, nil
+	}
+
+	entryPoints := []struct {
+		name    string
+		convert func(*Converter, []*JiraIssue) ([]*types.Issue, error)
+	}{
+		{"Convert", (*Converter).Convert},
+		{"ConvertWithDependencies", func(c *Converter, issues []*JiraIssue) ([]*types.Issue, error) {
+			result, err := c.ConvertWithDependencies(issues)
+			if err != nil {
+				return nil, err
+			}
+			return result.Issues, nil
+		}},
+		{"ConvertStream", stream},
+		{"ConvertConcurrent", func(c *Converter, issues []*JiraIssue) ([]*types.Issue, error) {
+			return c.ConvertConcurrent(issues, 4)
+		}},
+	}
+
+	for _, ep := range entryPoints {
+		t.Run(ep.name, func(t *testing.T) {
+			converter := NewConverter(ConverterConfig{SkipIssueTypes: []string{"Test", "test execution"}})
+			issues, err := ep.convert(converter, jiraIssues)
+			if err != nil {
+				t.Fatalf("%s() error = %v", ep.name, err)
+			}
+			var titles []string
+			for _, issue := range issues {
+				titles = append(titles, issue.Title)
+			}
+			if want := []string{"Bug", "Story"}; !reflect.DeepEqual(titles, want) {
+				t.Errorf("titles = %v, want %v", titles, want)
+			}
+		})
+	}
+}
+
+func TestConverter_DefaultBlockingLinkTypes(t *testing.T) {
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net", Prefix: "test"})
+
+	jiraIssues := []*JiraIssue{
+		{
+			Key: "PROJ-1",
+			Fields: JiraIssueFields{
+				Summary: "Issue",
+				IssueLinks: []*JiraIssueLink{
+					{Type: &JiraLinkType{Name: "Blocks"}, InwardIssue: &JiraLinkedIssue{Key: "PROJ-2"}},
+					{Type: &JiraLinkType{Name: "Gates"}, InwardIssue: &JiraLinkedIssue{Key: "PROJ-2"}},
+				},
+			},
+		},
+		{Key: "PROJ-2", Fields: JiraIssueFields{Summary: "Other"}},
+	}
+
+	result, err := converter.ConvertWithDependencies(jiraIssues)
+	if err != nil {
+		t.Fatalf("ConvertWithDependencies() error = %v", err)
+	}
+	if len(result.Dependencies) != 2 {
+		t.Fatalf("Dependencies count = %d, want 2", len(result.Dependencies))
+	}
+	if result.Dependencies[0].Type != types.DepBlocks {
+		t.Errorf("Blocks link type = %s, want %s", result.Dependencies[0].Type, types.DepBlocks)
+	}
+	if result.Dependencies[1].Type != types.DepRelated {
+		t.Errorf("Gates link type = %s, want %s", result.Dependencies[1].Type, types.DepRelated)
+	}
+}
+
+func TestConverter_Components(t *testing.T) {
+	newIssue := func() *JiraIssue {
+		return &JiraIssue{
+			Key: "PROJ-1",
+			Fields: JiraIssueFields{
+				Summary:    "Crash on launch",
+				Labels:     []string{"urgent"},
+				Components: []*JiraComponent{{ID: "1", Name: "Backend"}, {ID: "2", Name: "iOS"}},
+			},
+		}
+	}
+
+	t.Run("dedicated field", func(t *testing.T) {
+		converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net"})
+		issue, err := converter.ConvertOne(newIssue())
+		if err != nil {
+			t.Fatalf("ConvertOne() error = %v", err)
+		}
+		if !reflect.DeepEqual(issue.Components, []string{"Backend", "iOS"}) {
+			t.Errorf("Components = %v, want [Backend iOS]", issue.Components)
+		}
+		if !reflect.DeepEqual(issue.Labels, []string{"urgent"}) {
+			t.Errorf("Labels = %v, want [urgent]", issue.Labels)
+		}
+	})
+
+	t.Run("as labels", func(t *testing.T) {
+		converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net", ComponentsAsLabels: true})
+		jira := newIssue()
+		issue, err := converter.ConvertOne(jira)
+		if err != nil {
+			t.Fatalf("ConvertOne() error = %v", err)
+		}
+		want := []string{"urgent", "component:Backend", "component:iOS"}
+		if !reflect.DeepEqual(issue.Labels, want) {
+			t.Errorf("Labels = %v, want %v", issue.Labels, want)
+		}
+		if len(issue.Components) != 0 {
+			t.Errorf("Components = %v, want none", issue.Components)
+		}
+		if len(jira.Fields.Labels) != 1 {
+			t.Errorf("Jira labels modified: %v", jira.Fields.Labels)
+		}
+	})
+}
+
+func TestConverter_NamespacedTags(t *testing.T) {
+	newIssue := func() *JiraIssue {
+		return &JiraIssue{
+			Key: "PROJ-1",
+			Fields: JiraIssueFields{
+				Summary:     "Crash on launch",
+				Labels:      []string{"urgent", "mobile"},
+				Components:  []*JiraComponent{{ID: "1", Name: "iOS"}},
+				FixVersions: []*JiraVersion{{ID: "10", Name: "1.2"}, {ID: "11", Name: "1.3"}},
+			},
+		}
+	}
+
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net", NamespacedTags: true, ComponentsAsLabels: true})
+	jira := newIssue()
+	issue, err := converter.ConvertOne(jira)
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+	want := []string{"label:urgent", "label:mobile", "component:iOS", "version:1.2", "version:1.3"}
+	if !reflect.DeepEqual(issue.Labels, want) {
+		t.Errorf("Labels = %v, want %v", issue.Labels, want)
+	}
+	if !reflect.DeepEqual(issue.Components, []string{"iOS"}) || !reflect.DeepEqual(issue.FixVersions, []string{"1.2", "1.3"}) {
+		t.Errorf("Components = %v, FixVersions = %v", issue.Components, issue.FixVersions)
+	}
+	if !reflect.DeepEqual(jira.Fields.Labels, []string{"urgent", "mobile"}) {
+		t.Errorf("Jira labels modified: %v", jira.Fields.Labels)
+	}
+
+	plain, err := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net"}).ConvertOne(newIssue())
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+	if !reflect.DeepEqual(plain.Labels, []string{"urgent", "mobile"}) {
+		t.Errorf("Labels without NamespacedTags = %v, want unchanged", plain.Labels)
+	}
+}
+
+func TestConverter_Versions(t *testing.T) {
+	jira := &JiraIssue{
+		Key: "PROJ-1",
+		Fields: JiraIssueFields{
+			Summary: "Fix login",
+			FixVersions: []*JiraVersion{
+				{ID: "10", Name: "1.0", Released: true, ReleaseDate: "2024-01-15"},
+				{ID: "11", Name: "1.1", Released: false},
+			},
+			Versions: []*JiraVersion{{ID: "9", Name: "0.9", Released: true}},
+		},
+	}
+
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net"})
+	issue, err := converter.ConvertOne(jira)
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+	if !reflect.DeepEqual(issue.FixVersions, []string{"1.0", "1.1"}) {
+		t.Errorf("FixVersions = %v, want [1.0 1.1]", issue.FixVersions)
+	}
+	if !reflect.DeepEqual(issue.AffectsVersions, []string{"0.9"}) {
+		t.Errorf("AffectsVersions = %v, want [0.9]", issue.AffectsVersions)
+	}
+	if issue.Milestone != "" {
+		t.Errorf("Milestone = %q, want empty when not configured", issue.Milestone)
+	}
+
+	converter = NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net", FixVersionAsMilestone: true})
+	issue, err = converter.ConvertOne(jira)
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+	if issue.Milestone != "1.1" {
+		t.Errorf("Milestone = %q, want unreleased version 1.1", issue.Milestone)
+	}
+}
+
+func TestConverter_ConvertStream(t *testing.T) {
+	jiraIssues := []*JiraIssue{
+		{Key: "PROJ-1", Fields: JiraIssueFields{Summary: "First", Status: &JiraStatus{Name: "To Do"}}},
+		{Key: "PROJ-2", Fields: JiraIssueFields{Summary: "Second", Status: &JiraStatus{Name: "Done"}}},
+		{Key: "PROJ-3", Fields: JiraIssueFields{Summary: "Third"}},
+	}
+
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net", Prefix: "bd"})
+	var buf bytes.Buffer
+	if err := converter.ConvertStream(context.Background(), jiraIssues, &buf); err != nil {
+		t.Fatalf("ConvertStream() error = %v", err)
+	}
+
+	var lines int
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var issue types.Issue
+		if err := json.Unmarshal(scanner.Bytes(), &issue); err != nil {
+			t.Fatalf("line %d does not decode: %v", lines+1, err)
+		}
+		if issue.Title != jiraIssues[lines].Fields.Summary {
+			t.Errorf("line %d Title = %q, want %q", lines+1, issue.Title, jiraIssues[lines].Fields.Summary)
+		}
+		lines++
+	}
+	if lines != len(jiraIssues) {
+		t.Errorf("got %d lines, want %d", lines, len(jiraIssues))
+	}
+}
+
+func TestConverter_ConvertStreamCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net"})
+	var buf bytes.Buffer
+	err := converter.ConvertStream(ctx, []*JiraIssue{{Key: "PROJ-1"}}, &buf)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ConvertStream() error = %v, want context.Canceled", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("wrote %q after cancellation", buf.String())
+	}
+}
+
+func TestConverter_AccountIDs(t *testing.T) {
+	raw := `{
+		"key": "PROJ-1",
+		"fields": {
+			"summary": "Cloud issue",
+			"assignee": {"accountId": "5b10a2844c20165700ede21g", "displayName": "Alice Smith"},
+			"reporter": {"accountId": "5b10ac8d82e05b22cc7d4ef5", "displayName": "Bob Jones"}
+		}
+	}`
+	var jira JiraIssue
+	if err := json.Unmarshal([]byte(raw), &jira); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if jira.Fields.Assignee.AccountID != "5b10a2844c20165700ede21g" {
+		t.Fatalf("Assignee.AccountID = %q", jira.Fields.Assignee.AccountID)
+	}
+
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net"})
+	issue, err := converter.ConvertOne(&jira)
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+	if issue.Assignee != "Alice Smith" || issue.AssigneeID != "5b10a2844c20165700ede21g" {
+		t.Errorf("assignee = %q/%q, want Alice Smith/5b10a2844c20165700ede21g", issue.Assignee, issue.AssigneeID)
+	}
+	if issue.CreatedBy != "Bob Jones" || issue.ReporterID != "5b10ac8d82e05b22cc7d4ef5" {
+		t.Errorf("reporter = %q/%q, want Bob Jones/5b10ac8d82e05b22cc7d4ef5", issue.CreatedBy, issue.ReporterID)
+	}
+}
+
+func TestConverter_AccountIDOnlyUsers(t *testing.T) {
+	// GDPR-strict Cloud sites return users with only an accountId
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net"})
+	issue, err := converter.ConvertOne(&JiraIssue{
+		Key: "PROJ-1",
+		Fields: JiraIssueFields{
+			Summary:  "Strict issue",
+			Assignee: &JiraUser{AccountID: "5b10a2844c20165700ede21g"},
+			Reporter: &JiraUser{AccountID: "5b10ac8d82e05b22cc7d4ef5"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+	if issue.Assignee != "5b10a2844c20165700ede21g" {
+		t.Errorf("Assignee = %q, want account ID", issue.Assignee)
+	}
+	if issue.CreatedBy != "5b10ac8d82e05b22cc7d4ef5" {
+		t.Errorf("CreatedBy = %q, want account ID", issue.CreatedBy)
+	}
+}
+
+func TestConverter_LabelTransform(t *testing.T) {
+	converter := NewConverter(ConverterConfig{
+		JiraURL: "https://test.atlassian.net",
+		LabelTransform: func(label string) (string, bool) {
+			label = strings.ToLower(label)
+			return label, !strings.HasPrefix(label, "internal-")
+		},
+	})
+
+	jira := &JiraIssue{
+		Key: "PROJ-1",
+		Fields: JiraIssueFields{
+			Summary: "Labels",
+			Labels:  []string{"Backend", "internal-triage", "API", "backend", "Internal-Only"},
+		},
+	}
+	issue, err := converter.ConvertOne(jira)
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+	want := []string{"backend", "api"}
+	if !reflect.DeepEqual(issue.Labels, want) {
+		t.Errorf("Labels = %v, want %v", issue.Labels, want)
+	}
+}
+
+func TestConverter_DefaultAssigneeAndReporter(t *testing.T) {
+	converter := NewConverter(ConverterConfig{
+		JiraURL:         "https://test.atlassian.net",
+		DefaultAssignee: "triage-team",
+		DefaultReporter: "jira-import",
+	})
+
+	tests := []struct {
+		name         string
+		fields       JiraIssueFields
+		wantAssignee string
+		wantReporter string
+	}{
+		{
+			name:         "missing users use defaults",
+			fields:       JiraIssueFields{Summary: "Orphan"},
+			wantAssignee: "triage-team",
+			wantReporter: "jira-import",
+		},
+		{
+			name: "present users are kept",
+			fields: JiraIssueFields{
+				Summary:  "Owned",
+				Assignee: &JiraUser{DisplayName: "Alice"},
+				Reporter: &JiraUser{DisplayName: "Bob"},
+			},
+			wantAssignee: "Alice",
+			wantReporter: "Bob",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue, err := converter.ConvertOne(&JiraIssue{Key: "PROJ-1", Fields: tt.fields})
+			if err != nil {
+				t.Fatalf("ConvertOne() error = %v", err)
+			}
+			if issue.Assignee != tt.wantAssignee {
+				t.Errorf("Assignee = %q, want %q", issue.Assignee, tt.wantAssignee)
+			}
+			if issue.CreatedBy != tt.wantReporter {
+				t.Errorf("CreatedBy = %q, want %q", issue.CreatedBy, tt.wantReporter)
+			}
+		})
+	}
+}
+
+func TestConverter_SecurityAndEnvironment(t *testing.T) {
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net"})
+
+	tests := []struct {
+		name            string
+		fields          JiraIssueFields
+		wantSecurity    string
+		wantEnvironment string
+	}{
+		{
+			name: "string environment",
+			fields: JiraIssueFields{
+				Security:    &JiraSecurityLevel{ID: "10001", Name: "Internal"},
+				Environment: "Chrome 120 on macOS",
+			},
+			wantSecurity:    "Internal",
+			wantEnvironment: "Chrome 120 on macOS",
+		},
+		{
+			name: "ADF environment",
+			fields: JiraIssueFields{
+				Environment: map[string]any{
+					"type": "doc",
+					"content": []any{
+						map[string]any{"type": "paragraph", "content": []any{
+							map[string]any{"type": "text", "text": "Staging, build 42"},
+						}},
+					},
+				},
+			},
+			wantEnvironment: "Staging, build 42",
+		},
+		{
+			name: "absent",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue, err := converter.ConvertOne(&JiraIssue{Key: "PROJ-1", Fields: tt.fields})
+			if err != nil {
+				t.Fatalf("ConvertOne() error = %v", err)
+			}
+			if issue.SecurityLevel != tt.wantSecurity {
+				t.Errorf("SecurityLevel = %q, want %q", issue.SecurityLevel, tt.wantSecurity)
+			}
+			if issue.Environment != tt.wantEnvironment {
+				t.Errorf("Environment = %q, want %q", issue.Environment, tt.wantEnvironment)
+			}
+		})
+	}
+}
+
+func TestConverter_ConvertConcurrentMatchesConvert(t *testing.T) {
+	jiraIssues := make([]*JiraIssue, 50)
+	for i := range jiraIssues {
+		jiraIssues[i] = &JiraIssue{
+			Key: fmt.Sprintf("PROJ-%d", i+1),
+			Fields: JiraIssueFields{
+				Summary:  fmt.Sprintf("Issue %d", i+1),
+				Status:   &JiraStatus{Name: []string{"To Do", "In Progress", "Done"}[i%3]},
+				Priority: &JiraPriority{Name: "High"},
+				Labels:   []string{"batch"},
+				Created:  "2024-01-15T10:30:00.000+0000",
+				Updated:  "2024-01-16T10:30:00.000+0000",
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		cfg  ConverterConfig
+	}{
+		{"sequential fallback IDs", ConverterConfig{JiraURL: "https://test.atlassian.net", Prefix: "bd"}},
+		{"ID generator", ConverterConfig{
+			JiraURL: "https://test.atlassian.net",
+			IDGenerator: func(title string, _ time.Time) (string, error) {
+				return "bd-" + strings.ReplaceAll(strings.ToLower(title), " ", ""), nil
+			},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := NewConverter(tt.cfg).Convert(jiraIssues)
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+
+			converter := NewConverter(tt.cfg)
+			got, err := converter.ConvertConcurrent(jiraIssues, 8)
+			if err != nil {
+				t.Fatalf("ConvertConcurrent() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ConvertConcurrent() output differs from Convert()")
+			}
+			if id := converter.GetJiraKeyToBDIDMap()["PROJ-50"]; id != want[49].ID {
+				t.Errorf("key map PROJ-50 = %q, want %q", id, want[49].ID)
+			}
+		})
+	}
+}
+
+func TestConverter_ConvertConcurrentError(t *testing.T) {
+	converter := NewConverter(ConverterConfig{
+		JiraURL: "https://test.atlassian.net",
+		IDGenerator: func(title string, _ time.Time) (string, error) {
+			if title == "bad" {
+				return "", errors.New("boom")
+			}
+			return "bd-" + title, nil
+		},
+	})
+
+	_, err := converter.ConvertConcurrent([]*JiraIssue{
+		{Key: "PROJ-1", Fields: JiraIssueFields{Summary: "ok"}},
+		{Key: "PROJ-2", Fields: JiraIssueFields{Summary: "bad"}},
+	}, 2)
+	if err == nil || !strings.Contains(err.Error(), "PROJ-2") {
+		t.Errorf("ConvertConcurrent() error = %v, want error mentioning PROJ-2", err)
+	}
+}
+
+func TestConverter_DueDate(t *testing.T) {
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net"})
+
+	issue, err := converter.ConvertOne(&JiraIssue{Key: "PROJ-1", Fields: JiraIssueFields{DueDate: "2024-03-15"}})
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+	if want := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC); !issue.DueDate.Equal(want) {
+		t.Errorf("DueDate = %v, want %v", issue.DueDate, want)
+	}
+
+	issue, err = converter.ConvertOne(&JiraIssue{Key: "PROJ-2"})
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+	if !issue.DueDate.IsZero() {
+		t.Errorf("DueDate = %v, want zero when absent", issue.DueDate)
+	}
+}
+
+func TestConverter_IsSubtask(t *testing.T) {
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net"})
+
+	tests := []struct {
+		issueType *JiraIssueType
+		want      bool
+	}{
+		{&JiraIssueType{Name: "Sub-task", Subtask: true}, true},
+		{&JiraIssueType{Name: "Story"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.issueType.Name, func(t *testing.T) {
+			issue, err := converter.ConvertOne(&JiraIssue{Key: "PROJ-1", Fields: JiraIssueFields{IssueType: tt.issueType}})
+			if err != nil {
+				t.Fatalf("ConvertOne() error = %v", err)
+			}
+			if issue.IsSubtask != tt.want {
+				t.Errorf("IsSubtask = %v, want %v", issue.IsSubtask, tt.want)
+			}
+		})
+	}
+}
+
+func TestConverter_SubtaskTypeRules(t *testing.T) {
+	bugSubtask := &JiraIssueType{Name: "Bug Sub-task", Subtask: true}
+
+	tests := []struct {
+		name      string
+		rules     []SubtaskTypeRule
+		issueType *JiraIssueType
+		want      types.IssueType
+	}{
+		{"bug subtask", nil, bugSubtask, types.TypeBug},
+		{"plain subtask", nil, &JiraIssueType{Name: "Sub-task", Subtask: true}, types.TypeTask},
+		{"localized defect", nil, &JiraIssueType{Name: "Sous-tâche Defect", Subtask: true}, types.TypeBug},
+		{"not a subtask", nil, &JiraIssueType{Name: "Bug Report"}, types.TypeTask},
+		{"custom rules", []SubtaskTypeRule{{"Fehler", types.TypeBug}}, &JiraIssueType{Name: "Fehler-Unteraufgabe", Subtask: true}, types.TypeBug},
+		{"rules disabled", []SubtaskTypeRule{}, bugSubtask, types.TypeTask},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converter := NewConverter(ConverterConfig{SubtaskTypeRules: tt.rules})
+			issue, err := converter.ConvertOne(&JiraIssue{Key: "PROJ-1", Fields: JiraIssueFields{IssueType: tt.issueType}})
+			if err != nil {
+				t.Fatalf("ConvertOne() error = %v", err)
+			}
+			if issue.IssueType != tt.want {
+				t.Errorf("IssueType = %q, want %q", issue.IssueType, tt.want)
+			}
+			if issue.IsSubtask != tt.issueType.Subtask {
+				t.Errorf("IsSubtask = %v, want %v", issue.IsSubtask, tt.issueType.Subtask)
+			}
+		})
+	}
+}
+
+func TestConverter_Votes(t *testing.T) {
+	raw := `{"key": "PROJ-1", "fields": {"summary": "Popular", "votes": {"self": "https://test.atlassian.net/rest/api/3/issue/PROJ-1/votes", "votes": 7, "hasVoted": true}}}`
+	var jira JiraIssue
+	if err := json.Unmarshal([]byte(raw), &jira); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if jira.Fields.Votes == nil || !jira.Fields.Votes.HasVoted {
+		t.Errorf("Votes = %+v, want hasVoted decoded", jira.Fields.Votes)
+	}
+
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net"})
+	issue, err := converter.ConvertOne(&jira)
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+	if issue.VoteCount != 7 {
+		t.Errorf("VoteCount = %d, want 7", issue.VoteCount)
+	}
+
+	issue, err = converter.ConvertOne(&JiraIssue{Key: "PROJ-2"})
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+	if issue.VoteCount != 0 {
+		t.Errorf("VoteCount = %d, want 0 when absent", issue.VoteCount)
+	}
+}
+
+func TestConverter_TimeTracking(t *testing.T) {
+	raw := `{
+		"key": "PROJ-1",
+		"fields": {
+			"summary": "Tracked",
+			"timetracking": {
+				"originalEstimate": "1d",
+				"remainingEstimate": "2h",
+				"timeSpent": "6h",
+				"originalEstimateSeconds": 28800,
+				"remainingEstimateSeconds": 7200,
+				"timeSpentSeconds": 21600
+			}
+		}
+	}`
+	var jira JiraIssue
+	if err := json.Unmarshal([]byte(raw), &jira); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net"})
+	issue, err := converter.ConvertOne(&jira)
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+	if issue.OriginalEstimate != 8*time.Hour {
+		t.Errorf("OriginalEstimate = %v, want 8h", issue.OriginalEstimate)
+	}
+	if issue.RemainingEstimate != 2*time.Hour {
+		t.Errorf("RemainingEstimate = %v, want 2h", issue.RemainingEstimate)
+	}
+	if issue.TimeSpent != 6*time.Hour {
+		t.Errorf("TimeSpent = %v, want 6h", issue.TimeSpent)
+	}
+
+	issue, err = converter.ConvertOne(&JiraIssue{Key: "PROJ-2"})
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+	if issue.OriginalEstimate != 0 || issue.RemainingEstimate != 0 || issue.TimeSpent != 0 {
+		t.Errorf("time tracking = %v/%v/%v, want zeros when absent", issue.OriginalEstimate, issue.RemainingEstimate, issue.TimeSpent)
+	}
+}
+
+func TestConverter_EpicLinkFields(t *testing.T) {
+	raw := `[
+		{"key": "PROJ-1", "fields": {"summary": "Checkout epic", "issuetype": {"name": "Epic"}, "customfield_10011": "Checkout"}},
+		{"key": "PROJ-2", "fields": {"summary": "Story", "issuetype": {"name": "Story"}, "customfield_10014": "PROJ-1"}},
+		{"key": "PROJ-3", "fields": {"summary": "Next-gen story", "customfield_10014": null, "parent": {"key": "PROJ-1"}}}
+	]`
+	var jiraIssues []*JiraIssue
+	if err := json.Unmarshal([]byte(raw), &jiraIssues); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	converter := NewConverter(ConverterConfig{
+		JiraURL:         "https://test.atlassian.net",
+		Prefix:          "bd",
+		EpicLinkFieldID: "customfield_10014",
+		EpicNameFieldID: "customfield_10011",
+	})
+	result, err := converter.ConvertWithDependencies(jiraIssues)
+	if err != nil {
+		t.Fatalf("ConvertWithDependencies() error = %v", err)
+	}
+
+	if got := result.Issues[0].EpicName; got != "Checkout" {
+		t.Errorf("EpicName = %q, want Checkout", got)
+	}
+
+	// Epic Link custom field
+	linked := result.Issues[1]
+	if linked.ParentID != "bd-1" {
+		t.Errorf("epic link ParentID = %q, want bd-1", linked.ParentID)
+	}
+	if len(linked.Dependencies) != 1 || linked.Dependencies[0].Type != types.DepParentChild {
+		t.Errorf("epic link Dependencies = %+v, want parent-child", linked.Dependencies)
+	}
+
+	// Empty custom field falls back to parent
+	if got := result.Issues[2].ParentID; got != "bd-1" {
+		t.Errorf("parent fallback ParentID = %q, want bd-1", got)
+	}
+}
+
+func TestConverter_MaxDescriptionLength(t *testing.T) {
+	adfDesc := map[string]any{
+		"type": "doc",
+		"content": []any{map[string]any{
+			"type":    "paragraph",
+			"content": []any{map[string]any{"type": "text", "text": "Größe über alles, and then a great deal more text"}},
+		}},
+	}
+
+	tests := []struct {
+		name  string
+		limit int
+		desc  any
+		want  string
+	}{
+		{"ADF cut at rune boundary", 8, adfDesc, "Größe ü…"},
+		{"exactly at limit", 5, "short", "short"},
+		{"one over limit", 5, "shorts", "shor…"},
+		{"limit of one", 1, "short", "…"},
+		{"under limit", 100, "short", "short"},
+		{"unlimited", 0, adfDesc, "Größe über alles, and then a great deal more text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net", MaxDescriptionLength: tt.limit})
+			issue, err := converter.ConvertOne(&JiraIssue{Key: "PROJ-1", Fields: JiraIssueFields{Summary: "Long", Description: tt.desc}})
+			if err != nil {
+				t.Fatalf("ConvertOne() error = %v", err)
+			}
+			if issue.Description != tt.want {
+				t.Errorf("Description = %q, want %q", issue.Description, tt.want)
+			}
+			// A cut description uses the whole limit, ellipsis included
+			n := utf8.RuneCountInString(issue.Description)
+			if cut := strings.HasSuffix(tt.want, "…"); cut && n != tt.limit || tt.limit > 0 && n > tt.limit {
+				t.Errorf("Description is %d runes, want %d at most (exactly if cut)", n, tt.limit)
+			}
+		})
+	}
+}
+
+func TestConverter_NormalizeText(t *testing.T) {
+	// Curly quotes, NBSP, a zero-width space, and a decomposed "é" (e + U+0301)
+	const pasted = "\u201cIt\u2019s\u00a0done\u201d\u200b, cafe\u0301"
+
+	tests := []struct {
+		name      string
+		normalize bool
+		want      string
+	}{
+		{"normalized", true, "\"It's done\", caf\u00e9"},
+		{"off by default", false, pasted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net", NormalizeText: tt.normalize})
+			issue, err := converter.ConvertOne(&JiraIssue{Key: "PROJ-1", Fields: JiraIssueFields{
+				Summary:     "Pasted",
+				Description: adfDoc(adfParagraph(pasted)),
+				Environment: pasted,
+			}})
+			if err != nil {
+				t.Fatalf("ConvertOne() error = %v", err)
+			}
+			if issue.Description != tt.want {
+				t.Errorf("Description = %q, want %q", issue.Description, tt.want)
+			}
+			if issue.Environment != tt.want {
+				t.Errorf("Environment = %q, want %q", issue.Environment, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package jira
+
+import (
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Epic handling modes for ConverterConfig.EpicHandling.
+const (
+	EpicAsIssue     = "issue"     // Convert epics to bd issues of type epic (default)
+	EpicSkip        = "skip"      // Drop epics from the converted issues
+	EpicAsMilestone = "milestone" // Convert epics to Milestone records
+)
+
+// Milestone is a Jira epic converted with EpicAsMilestone. Issues in the
+// epic have Issue.Milestone set to its Name instead of a parent link.
+type Milestone struct {
+	Key         string
+	Name        string // Epic name if configured and set, otherwise the summary
+	Description string
+	Status      types.Status
+	DueDate     time.Time
+}
+
+// excludesEpic reports whether jira is an epic that EpicHandling keeps out
+// of the converted issues.
+func (c *Converter) excludesEpic(jira *JiraIssue) bool {
+	if c.epicHandling != EpicSkip && c.epicHandling != EpicAsMilestone {
+		return false
+	}
+	return c.mapIssueType(jira.Fields.IssueType) == types.TypeEpic
+}
+
+// convertMilestone builds the Milestone record for an epic.
+func (c *Converter) convertMilestone(jira *JiraIssue) *Milestone {
+	m := &Milestone{
+		Key:         jira.Key,
+		Name:        jira.Fields.Summary,
+		Description: truncateRunes(c.renderText(jira.Fields.Description), c.maxDescriptionLen),
+		Status:      c.mapStatus(jira.Fields.Status),
+	}
+	if c.epicNameField != "" {
+		if name := parseStringField(jira.Fields.Raw[c.epicNameField]); name != "" {
+			m.Name = name
+		}
+	}
+	if dueDate, err := time.Parse(jiraDateLayout, jira.Fields.DueDate); err == nil {
+		m.DueDate = dueDate
+	}
+	return m
+}
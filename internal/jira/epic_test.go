@@ -0,0 +1,175 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestConverter_EpicHandling(t *testing.T) {
+	newIssues := func() []*JiraIssue {
+		return []*JiraIssue{
+			{Key: "PROJ-1", Fields: JiraIssueFields{
+				Summary:   "Checkout revamp",
+				IssueType: &JiraIssueType{Name: "Epic"},
+				Status:    &JiraStatus{Name: "In Progress"},
+				DueDate:   "2024-06-30",
+			}},
+			{Key: "PROJ-2", Fields: JiraIssueFields{
+				Summary:   "Pay with card",
+				IssueType: &JiraIssueType{Name: "Story"},
+				Parent:    &JiraParent{Key: "PROJ-1"},
+			}},
+			{Key: "PROJ-3", Fields: JiraIssueFields{
+				Summary:   "Unrelated",
+				IssueType: &JiraIssueType{Name: "Story"},
+			}},
+		}
+	}
+	convert := func(t *testing.T, mode string) *ConversionResult {
+		t.Helper()
+		converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net", Prefix: "bd", EpicHandling: mode})
+		result, err := converter.ConvertWithDependencies(newIssues())
+		if err != nil {
+			t.Fatalf("ConvertWithDependencies() error = %v", err)
+		}
+		return result
+	}
+
+	t.Run("issue", func(t *testing.T) {
+		result := convert(t, EpicAsIssue)
+		if len(result.Issues) != 3 || result.Issues[0].IssueType != types.TypeEpic {
+			t.Fatalf("got %d issues, first type %q; want 3 with an epic", len(result.Issues), result.Issues[0].IssueType)
+		}
+		if result.Issues[1].ParentID != result.Issues[0].ID {
+			t.Errorf("story ParentID = %q, want %q", result.Issues[1].ParentID, result.Issues[0].ID)
+		}
+		if len(result.Milestones) != 0 {
+			t.Errorf("Milestones = %v, want none", result.Milestones)
+		}
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		result := convert(t, EpicSkip)
+		if len(result.Issues) != 2 || result.Issues[0].Title != "Pay with card" {
+			t.Fatalf("got %d issues, want the 2 stories", len(result.Issues))
+		}
+		story := result.Issues[0]
+		if story.ParentID != "" || story.Milestone != "" || len(story.Dependencies) != 0 {
+			t.Errorf("story parent = %q, milestone = %q, deps = %d; want none", story.ParentID, story.Milestone, len(story.Dependencies))
+		}
+		if len(result.Unresolved) != 0 || len(result.Milestones) != 0 {
+			t.Errorf("Unresolved = %v, Milestones = %v; want none", result.Unresolved, result.Milestones)
+		}
+	})
+
+	t.Run("milestone", func(t *testing.T) {
+		result := convert(t, EpicAsMilestone)
+		if len(result.Issues) != 2 {
+			t.Fatalf("got %d issues, want the 2 stories", len(result.Issues))
+		}
+		if len(result.Milestones) != 1 {
+			t.Fatalf("got %d milestones, want 1", len(result.Milestones))
+		}
+		m := result.Milestones[0]
+		if m.Key != "PROJ-1" || m.Name != "Checkout revamp" || m.Status != types.StatusInProgress || m.DueDate.Format(jiraDateLayout) != "2024-06-30" {
+			t.Errorf("milestone = %+v", m)
+		}
+		if got := result.Issues[0].Milestone; got != "Checkout revamp" {
+			t.Errorf("story Milestone = %q, want Checkout revamp", got)
+		}
+		if result.Issues[0].ParentID != "" || result.Issues[1].Milestone != "" {
+			t.Errorf("ParentID = %q, unrelated Milestone = %q; want empty", result.Issues[0].ParentID, result.Issues[1].Milestone)
+		}
+	})
+}
+
+func TestConverter_EpicHandlingConcurrentMatchesConvert(t *testing.T) {
+	jiraIssues := []*JiraIssue{
+		{Key: "PROJ-1", Fields: JiraIssueFields{Summary: "Checkout revamp", IssueType: &JiraIssueType{Name: "Epic"}}},
+		{Key: "PROJ-2", Fields: JiraIssueFields{Summary: "Pay with card", IssueType: &JiraIssueType{Name: "Story"}, Parent: &JiraParent{Key: "PROJ-1"}}},
+		{Key: "PROJ-3", Fields: JiraIssueFields{Summary: "Validate card", IssueType: &JiraIssueType{Name: "Sub-task", Subtask: true}, Parent: &JiraParent{Key: "PROJ-2"}}},
+		{Key: "PROJ-4", Fields: JiraIssueFields{Summary: "Unrelated", IssueType: &JiraIssueType{Name: "Story"}}},
+	}
+	for _, jira := range jiraIssues {
+		jira.Fields.Created = "2024-01-15T10:30:00.000+0000"
+		jira.Fields.Updated = "2024-01-16T10:30:00.000+0000"
+	}
+
+	for _, mode := range []string{EpicAsIssue, EpicSkip, EpicAsMilestone} {
+		t.Run(mode, func(t *testing.T) {
+			cfg := ConverterConfig{JiraURL: "https://test.atlassian.net", Prefix: "bd", EpicHandling: mode}
+			want, err := NewConverter(cfg).Convert(jiraIssues)
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			for _, issue := range want {
+				issue.Dependencies = nil // Not resolved by ConvertConcurrent
+			}
+
+			got, err := NewConverter(cfg).ConvertConcurrent(jiraIssues, 4)
+			if err != nil {
+				t.Fatalf("ConvertConcurrent() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ConvertConcurrent() output differs from Convert()")
+				for i := range max(len(got), len(want)) {
+					if i >= len(got) || i >= len(want) {
+						break
+					}
+					t.Logf("%d: got %s parent %q milestone %q; want %s parent %q milestone %q", i,
+						got[i].Title, got[i].ParentID, got[i].Milestone, want[i].Title, want[i].ParentID, want[i].Milestone)
+				}
+			}
+		})
+	}
+}
+
+func TestConverter_EpicHandlingStream(t *testing.T) {
+	jiraIssues := []*JiraIssue{
+		{Key: "PROJ-1", Fields: JiraIssueFields{Summary: "Checkout revamp", IssueType: &JiraIssueType{Name: "Epic"}}},
+		{Key: "PROJ-2", Fields: JiraIssueFields{Summary: "Pay with card", IssueType: &JiraIssueType{Name: "Story"}, Parent: &JiraParent{Key: "PROJ-1"}}},
+	}
+
+	tests := []struct {
+		mode          string
+		wantTitles    []string
+		wantMilestone string
+	}{
+		{EpicAsIssue, []string{"Checkout revamp", "Pay with card"}, ""},
+		{EpicSkip, []string{"Pay with card"}, ""},
+		{EpicAsMilestone, []string{"Pay with card"}, "Checkout revamp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net", EpicHandling: tt.mode})
+			var buf bytes.Buffer
+			if err := converter.ConvertStream(context.Background(), jiraIssues, &buf); err != nil {
+				t.Fatalf("ConvertStream() error = %v", err)
+			}
+
+			var titles []string
+			var story types.Issue
+			dec := json.NewDecoder(&buf)
+			for dec.More() {
+				var issue types.Issue
+				if err := dec.Decode(&issue); err != nil {
+					t.Fatalf("Decode() error = %v", err)
+				}
+				titles = append(titles, issue.Title)
+				story = issue
+			}
+			if !reflect.DeepEqual(titles, tt.wantTitles) {
+				t.Errorf("titles = %v, want %v", titles, tt.wantTitles)
+			}
+			if story.Milestone != tt.wantMilestone {
+				t.Errorf("story Milestone = %q, want %q", story.Milestone, tt.wantMilestone)
+			}
+		})
+	}
+}
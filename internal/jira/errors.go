@@ -0,0 +1,156 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrNotFound matches, via errors.Is, any APIError for a 404 response, such
+// as a lookup of an issue or project that does not exist or is not visible.
+var ErrNotFound = errors.New("jira: not found")
+
+// ErrorKind classifies Jira API errors so callers can react programmatically.
+type ErrorKind int
+
+const (
+	ErrorKindUnknown      ErrorKind = iota
+	ErrorKindUnauthorized           // 401: bad or missing credentials
+	ErrorKindForbidden              // 403: authenticated but not permitted
+	ErrorKindBadRequest             // 400: invalid request or JQL
+	ErrorKindRateLimited            // 429: too many requests
+	ErrorKindServer                 // 5xx: Jira-side failure
+	ErrorKindNotFound               // 404: resource does not exist or is not visible
+)
+
+// String returns the lowercase name of the error kind.
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindUnauthorized:
+		return "unauthorized"
+	case ErrorKindForbidden:
+		return "forbidden"
+	case ErrorKindBadRequest:
+		return "bad_request"
+	case ErrorKindRateLimited:
+		return "rate_limited"
+	case ErrorKindServer:
+		return "server"
+	case ErrorKindNotFound:
+		return "not_found"
+	default:
+		return "unknown"
+	}
+}
+
+// APIError is returned when Jira responds with a non-success status.
+type APIError struct {
+	StatusCode int
+	Body       string
+	Kind       ErrorKind
+	message    string
+}
+
+func (e *APIError) Error() string {
+	return e.message
+}
+
+// Is reports whether the error matches target, so that
+// errors.Is(err, ErrNotFound) holds for 404 responses.
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.Kind == ErrorKindNotFound
+}
+
+// PartialResultsError is returned by SearchIssuesWithOptions with
+// AllowPartial set when a search fails after some pages were fetched.
+// Issues holds everything fetched before the failure; StartAt (offset
+// paging) or PageToken (token paging) is where to resume.
+type PartialResultsError struct {
+	Issues    []*JiraIssue
+	StartAt   int
+	PageToken string
+	Err       error
+}
+
+func (e *PartialResultsError) Error() string {
+	return fmt.Sprintf("search failed after %d issues (resume at %d): %v", len(e.Issues), e.StartAt, e.Err)
+}
+
+func (e *PartialResultsError) Unwrap() error {
+	return e.Err
+}
+
+// redactedMarker replaces credentials scrubbed from error text.
+const redactedMarker = "[REDACTED]"
+
+// redact replaces any occurrence of the configured credentials, raw or as
+// the encoded Authorization header value, in s.
+func (c *Client) redact(s string) string {
+	secrets := []string{c.apiToken, c.accessToken}
+	if auth := c.authHeader(); auth != "" {
+		// The header value minus its scheme, e.g. the base64 Basic credential
+		if _, credential, ok := strings.Cut(auth, " "); ok {
+			secrets = append(secrets, credential)
+		}
+	}
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, redactedMarker)
+		}
+	}
+	return s
+}
+
+// errorKindForStatus maps an HTTP status code to an ErrorKind.
+func errorKindForStatus(statusCode int) ErrorKind {
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		return ErrorKindUnauthorized
+	case statusCode == http.StatusForbidden:
+		return ErrorKindForbidden
+	case statusCode == http.StatusBadRequest:
+		return ErrorKindBadRequest
+	case statusCode == http.StatusTooManyRequests:
+		return ErrorKindRateLimited
+	case statusCode == http.StatusNotFound:
+		return ErrorKindNotFound
+	case statusCode >= 500:
+		return ErrorKindServer
+	default:
+		return ErrorKindUnknown
+	}
+}
+
+// handleAPIError creates descriptive error messages for API errors.
+// Credentials echoed in the response body are redacted.
+func (c *Client) handleAPIError(statusCode int, body []byte) error {
+	body = []byte(c.redact(string(body)))
+	msg := fmt.Sprintf("Jira API error %d", statusCode)
+
+	switch statusCode {
+	case http.StatusUnauthorized:
+		msg += "\nAuthentication failed. Check your credentials."
+		if c.isCloud {
+			msg += "\nFor Jira Cloud, use your email as username and an API token."
+			msg += "\nCreate a token at: https://id.atlassian.com/manage-profile/security/api-tokens"
+		} else {
+			msg += "\nFor Jira Server/DC, use a Personal Access Token or username/password."
+		}
+	case http.StatusForbidden:
+		msg += fmt.Sprintf("\nAccess forbidden. Check permissions for project.\n%s", string(body))
+	case http.StatusBadRequest:
+		msg += fmt.Sprintf("\nBad request (invalid JQL?): %s", string(body))
+	case http.StatusNotFound:
+		msg += fmt.Sprintf("\nNot found (does it exist and can you see it?): %s", string(body))
+	default:
+		msg += fmt.Sprintf("\n%s", string(body))
+	}
+
+	return &APIError{
+		StatusCode: statusCode,
+		Body:       string(body),
+		Kind:       errorKindForStatus(statusCode),
+		message:    msg,
+	}
+}
@@ -0,0 +1,147 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHandleAPIError_Kind(t *testing.T) {
+	client := newTestClient(t, "https://jira.example.com")
+
+	tests := []struct {
+		statusCode int
+		want       ErrorKind
+	}{
+		{http.StatusUnauthorized, ErrorKindUnauthorized},
+		{http.StatusForbidden, ErrorKindForbidden},
+		{http.StatusBadRequest, ErrorKindBadRequest},
+		{http.StatusTooManyRequests, ErrorKindRateLimited},
+		{http.StatusNotFound, ErrorKindNotFound},
+		{http.StatusInternalServerError, ErrorKindServer},
+		{http.StatusServiceUnavailable, ErrorKindServer},
+		{http.StatusConflict, ErrorKindUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.statusCode), func(t *testing.T) {
+			err := client.handleAPIError(tt.statusCode, []byte(`{"errorMessages": ["details"]}`))
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("handleAPIError() = %T, want *APIError", err)
+			}
+			if apiErr.Kind != tt.want {
+				t.Errorf("Kind = %v, want %v", apiErr.Kind, tt.want)
+			}
+			if apiErr.StatusCode != tt.statusCode {
+				t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, tt.statusCode)
+			}
+			if apiErr.Body != `{"errorMessages": ["details"]}` {
+				t.Errorf("Body = %q", apiErr.Body)
+			}
+		})
+	}
+}
+
+func TestHandleAPIError_Message(t *testing.T) {
+	client := newTestClient(t, "https://jira.example.com")
+
+	err := client.handleAPIError(http.StatusUnauthorized, nil)
+	if !strings.HasPrefix(err.Error(), "Jira API error 401\nAuthentication failed.") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
+
+func TestAPIError_ThroughSearch(t *testing.T) {
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusBadRequest, `{"errorMessages": ["bad JQL"]}`), nil
+	})
+
+	_, err := client.SearchIssues(context.Background(), "nonsense ===", "all")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Kind != ErrorKindBadRequest {
+		t.Errorf("SearchIssues() error = %v, want bad request APIError", err)
+	}
+}
+
+func TestErrNotFound_GetIssue(t *testing.T) {
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/rest/api/3/issue/PROJ-404" {
+			t.Errorf("unexpected path %q", req.URL.Path)
+		}
+		return jsonResponse(http.StatusNotFound, `{"errorMessages": ["Issue does not exist or you do not have permission to see it."]}`), nil
+	})
+
+	_, err := client.GetIssue(context.Background(), "PROJ-404")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetIssue() error = %v, want ErrNotFound", err)
+	}
+	if !strings.Contains(err.Error(), "Issue does not exist") {
+		t.Errorf("error = %q, want response body in message", err)
+	}
+
+	// Other failures must not match
+	if errors.Is(client.handleAPIError(http.StatusForbidden, nil), ErrNotFound) {
+		t.Error("403 error matches ErrNotFound")
+	}
+}
+
+func TestSearchIssues_PartialResults(t *testing.T) {
+	newClient := func() *Client {
+		client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("startAt") == "0" {
+				return jsonResponse(http.StatusOK, searchPageJSON(0, searchPageSize, 250)), nil
+			}
+			return jsonResponse(http.StatusInternalServerError, `{"errorMessages": ["boom"]}`), nil
+		})
+		client.concurrency = 1
+		return client
+	}
+
+	// Default: all or nothing
+	issues, err := newClient().SearchIssuesWithOptions(context.Background(), SearchOptions{State: "all"})
+	if err == nil || issues != nil {
+		t.Fatalf("SearchIssuesWithOptions() = %d issues, %v; want nil, error", len(issues), err)
+	}
+
+	issues, err = newClient().SearchIssuesWithOptions(context.Background(), SearchOptions{State: "all", AllowPartial: true})
+	var partial *PartialResultsError
+	if !errors.As(err, &partial) {
+		t.Fatalf("SearchIssuesWithOptions() error = %v, want *PartialResultsError", err)
+	}
+	assertSequentialKeys(t, issues, searchPageSize)
+	if len(partial.Issues) != searchPageSize || partial.StartAt != searchPageSize {
+		t.Errorf("partial = %d issues, StartAt %d; want %d, %d", len(partial.Issues), partial.StartAt, searchPageSize, searchPageSize)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Kind != ErrorKindServer {
+		t.Errorf("underlying error = %v, want server APIError", err)
+	}
+}
+
+func TestHandleAPIError_RedactsCredentials(t *testing.T) {
+	client, err := NewClient(Config{URL: "https://jira.example.com", Username: "user", APIToken: "s3cr3t-api-token"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	basic := strings.TrimPrefix(client.authHeader(), "Basic ")
+
+	body := `{"errorMessages": ["bad request with token=s3cr3t-api-token and header Basic ` + basic + `"]}`
+	err = client.handleAPIError(http.StatusBadRequest, []byte(body))
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("handleAPIError() = %T, want *APIError", err)
+	}
+	for _, secret := range []string{"s3cr3t-api-token", basic} {
+		if strings.Contains(err.Error(), secret) || strings.Contains(apiErr.Body, secret) {
+			t.Errorf("error %q still contains %q", err.Error(), secret)
+		}
+	}
+	if !strings.Contains(apiErr.Body, "token="+redactedMarker) {
+		t.Errorf("Body = %q, want token redacted", apiErr.Body)
+	}
+}
@@ -0,0 +1,119 @@
+package jira
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// csvHeader is the header row written by WriteCSV.
+var csvHeader = []string{"id", "title", "status", "type", "priority", "assignee", "reporter", "labels", "external_ref"}
+
+// WriteCSV writes converted issues as CSV with a header row, one row per
+// issue. Labels are joined with semicolons. Fields containing commas, quotes,
+// or newlines are quoted per RFC 4180.
+func WriteCSV(w io.Writer, issues []*types.Issue) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, issue := range issues {
+		externalRef := ""
+		if issue.ExternalRef != nil {
+			externalRef = *issue.ExternalRef
+		}
+		record := []string{
+			issue.ID,
+			issue.Title,
+			string(issue.Status),
+			string(issue.IssueType),
+			strconv.Itoa(issue.Priority),
+			issue.Assignee,
+			issue.CreatedBy,
+			strings.Join(issue.Labels, ";"),
+			externalRef,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteMarkdown writes converted issues as Markdown, one section per issue:
+// an "ID: title" heading, a status/type/priority/assignee line, a link to
+// the external ref, labels as inline code, and the description.
+func WriteMarkdown(w io.Writer, issues []*types.Issue) error {
+	for i, issue := range issues {
+		var sb strings.Builder
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "## %s: %s\n\n", issue.ID, issue.Title)
+
+		assignee := issue.Assignee
+		if assignee == "" {
+			assignee = "unassigned"
+		}
+		fmt.Fprintf(&sb, "**Status:** %s | **Type:** %s | **Priority:** P%d | **Assignee:** %s\n",
+			issue.Status, issue.IssueType, issue.Priority, assignee)
+
+		if issue.ExternalRef != nil && *issue.ExternalRef != "" {
+			fmt.Fprintf(&sb, "\n[%s](%s)\n", *issue.ExternalRef, *issue.ExternalRef)
+		}
+		if len(issue.Labels) > 0 {
+			sb.WriteString("\nLabels:")
+			for _, label := range issue.Labels {
+				sb.WriteString(" `" + label + "`")
+			}
+			sb.WriteString("\n")
+		}
+		if issue.Description != "" {
+			sb.WriteString("\n" + strings.TrimRight(issue.Description, "\n") + "\n")
+		}
+
+		if _, err := io.WriteString(w, sb.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// JSONSchemaVersion identifies the issue format written by WriteJSON, so
+// consumers can detect changes to it.
+const JSONSchemaVersion = "beads/v1"
+
+// JSONOptions configures WriteJSON.
+type JSONOptions struct {
+	// Bare writes the plain issue array without the schema envelope, for
+	// consumers that predate it.
+	Bare bool
+}
+
+// jsonEnvelope is the top-level object written by WriteJSON.
+type jsonEnvelope struct {
+	Schema string         `json:"schema"`
+	Issues []*types.Issue `json:"issues"`
+}
+
+// WriteJSON writes converted issues as a single JSON document: by default
+// an envelope {"schema": JSONSchemaVersion, "issues": [...]}, or the bare
+// array if opts.Bare is set. No issues are written as an empty array.
+func WriteJSON(w io.Writer, issues []*types.Issue, opts JSONOptions) error {
+	if issues == nil {
+		issues = []*types.Issue{}
+	}
+	var doc any = jsonEnvelope{Schema: JSONSchemaVersion, Issues: issues}
+	if opts.Bare {
+		doc = issues
+	}
+	return json.NewEncoder(w).Encode(doc)
+}
@@ -0,0 +1,140 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWriteCSV(t *testing.T) {
+	ref := "https://test.atlassian.net/browse/PROJ-1"
+	issues := []*types.Issue{
+		{
+			ID:          "bd-1",
+			Title:       "Fix login, again",
+			Status:      types.StatusInProgress,
+			IssueType:   types.TypeBug,
+			Priority:    1,
+			Assignee:    "Alice",
+			CreatedBy:   "Bob",
+			Labels:      []string{"auth", "urgent"},
+			ExternalRef: &ref,
+		},
+		{
+			ID:        "bd-2",
+			Title:     "Multi\nline \"title\"",
+			Status:    types.StatusOpen,
+			IssueType: types.TypeTask,
+			Priority:  2,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, issues); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want header + 2 rows", len(records))
+	}
+
+	wantHeader := []string{"id", "title", "status", "type", "priority", "assignee", "reporter", "labels", "external_ref"}
+	if !reflect.DeepEqual(records[0], wantHeader) {
+		t.Errorf("header = %v, want %v", records[0], wantHeader)
+	}
+	wantRow := []string{"bd-1", "Fix login, again", "in_progress", "bug", "1", "Alice", "Bob", "auth;urgent", ref}
+	if !reflect.DeepEqual(records[1], wantRow) {
+		t.Errorf("row 1 = %v, want %v", records[1], wantRow)
+	}
+	if records[2][1] != "Multi\nline \"title\"" {
+		t.Errorf("row 2 title = %q, want it to round-trip", records[2][1])
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	ref := "https://test.atlassian.net/browse/PROJ-1"
+	issues := []*types.Issue{{
+		ID:          "bd-1",
+		Title:       "Fix login",
+		Description: "Users cannot log in.",
+		Status:      types.StatusInProgress,
+		IssueType:   types.TypeBug,
+		Priority:    1,
+		Assignee:    "Alice",
+		Labels:      []string{"auth", "urgent"},
+		ExternalRef: &ref,
+	}}
+
+	var buf bytes.Buffer
+	if err := WriteMarkdown(&buf, issues); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+
+	want := "## bd-1: Fix login\n\n" +
+		"**Status:** in_progress | **Type:** bug | **Priority:** P1 | **Assignee:** Alice\n\n" +
+		"[" + ref + "](" + ref + ")\n\n" +
+		"Labels: `auth` `urgent`\n\n" +
+		"Users cannot log in.\n"
+	if got := buf.String(); got != want {
+		t.Errorf("WriteMarkdown() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "First", Status: types.StatusOpen, IssueType: types.TypeTask, Priority: 2},
+		{ID: "bd-2", Title: "Second", Status: types.StatusClosed, IssueType: types.TypeBug, Priority: 1},
+	}
+
+	t.Run("envelope", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteJSON(&buf, issues, JSONOptions{}); err != nil {
+			t.Fatalf("WriteJSON() error = %v", err)
+		}
+		var doc struct {
+			Schema string         `json:"schema"`
+			Issues []*types.Issue `json:"issues"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+			t.Fatalf("output is not an envelope: %v\n%s", err, buf.String())
+		}
+		if doc.Schema != "beads/v1" {
+			t.Errorf("schema = %q, want beads/v1", doc.Schema)
+		}
+		if len(doc.Issues) != 2 || doc.Issues[0].ID != "bd-1" || doc.Issues[1].Title != "Second" {
+			t.Errorf("issues = %+v, want bd-1 and bd-2", doc.Issues)
+		}
+	})
+
+	t.Run("bare", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteJSON(&buf, issues, JSONOptions{Bare: true}); err != nil {
+			t.Fatalf("WriteJSON() error = %v", err)
+		}
+		var got []*types.Issue
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("output is not an array: %v\n%s", err, buf.String())
+		}
+		if len(got) != 2 || got[0].ID != "bd-1" || got[1].ID != "bd-2" {
+			t.Errorf("issues = %+v, want bd-1 and bd-2", got)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteJSON(&buf, nil, JSONOptions{}); err != nil {
+			t.Fatalf("WriteJSON() error = %v", err)
+		}
+		if got, want := buf.String(), `{"schema":"beads/v1","issues":[]}`+"\n"; got != want {
+			t.Errorf("WriteJSON(nil) = %q, want %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,303 @@
+// Package jiratest provides a fake Jira REST API for testing code that uses
+// the jira package's Client without network access.
+//
+// A Server serves canned issues for search and issue lookups, and workflow
+// transitions for TransitionIssue, under both /rest/api/2 and /rest/api/3.
+// Every request is recorded so tests can assert on what the client sent:
+//
+//	srv := jiratest.NewServer(t)
+//	srv.AddIssue(jiratest.Issue("PROJ-1", "First issue"))
+//	client, _ := jira.NewClient(jira.Config{URL: srv.URL, APIToken: "token"})
+//	...
+//	for _, req := range srv.Requests() {
+//		// req.Method, req.Path, req.Query, req.Body
+//	}
+package jiratest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// DefaultPageSize is the number of issues per search page unless
+// Server.PageSize is set.
+const DefaultPageSize = 50
+
+// Request is a request received by the Server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Transition is a workflow transition offered for an issue.
+type Transition struct {
+	ID   string
+	Name string
+	To   string // Target status name; omitted from responses when empty
+}
+
+// Server is a fake Jira instance backed by an httptest.Server. Configure it
+// before the client starts sending requests; the canned data methods are
+// safe to call concurrently with requests.
+type Server struct {
+	*httptest.Server
+
+	// PageSize caps the issues returned per search page. Zero uses
+	// DefaultPageSize.
+	PageSize int
+	// TokenPaging makes /rest/api/3/search/jql page with nextPageToken, as
+	// Jira Cloud does, instead of reporting startAt and total.
+	TokenPaging bool
+
+	mu          sync.Mutex
+	issues      []map[string]any
+	transitions map[string][]Transition
+	failures    []failure
+	requests    []Request
+}
+
+// failure is a queued error response.
+type failure struct {
+	status int
+	body   string
+}
+
+// NewServer starts a fake Jira server that is closed when the test ends.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+	s := &Server{transitions: make(map[string][]Transition)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Issue builds a minimal issue with the given key and summary, suitable for
+// AddIssue. Add further fields to its "fields" map as needed.
+func Issue(key, summary string) map[string]any {
+	return map[string]any{
+		"key": key,
+		"fields": map[string]any{
+			"summary": summary,
+			"status":  map[string]any{"name": "To Do"},
+		},
+	}
+}
+
+// AddIssue adds issues, in the JSON shape Jira returns, to the canned data.
+// Each must have a "key". Searches return issues in the order added.
+func (s *Server) AddIssue(issues ...map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.issues = append(s.issues, issues...)
+}
+
+// SetTransitions sets the transitions available for an issue.
+func (s *Server) SetTransitions(key string, transitions ...Transition) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transitions[key] = transitions
+}
+
+// FailNext makes the next request fail with the given status and body,
+// e.g. to exercise retries. Calls queue up, one failure per request.
+func (s *Server) FailNext(status int, body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, failure{status: status, body: body})
+}
+
+// Requests returns the requests received so far, in arrival order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsTo returns the recorded requests whose path ends with suffix,
+// e.g. "/transitions".
+func (s *Server) RequestsTo(suffix string) []Request {
+	var matched []Request
+	for _, req := range s.Requests() {
+		if strings.HasSuffix(req.Path, suffix) {
+			matched = append(matched, req)
+		}
+	}
+	return matched
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	var fail *failure
+	if len(s.failures) > 0 {
+		fail = &s.failures[0]
+		s.failures = s.failures[1:]
+	}
+	s.mu.Unlock()
+
+	if fail != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(fail.status)
+		_, _ = io.WriteString(w, fail.body)
+		return
+	}
+
+	// Strip the context path and API version: /rest/api/{2,3}/...
+	_, path, ok := strings.Cut(r.URL.Path, "/rest/api/")
+	if ok {
+		_, path, ok = strings.Cut(path, "/")
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "no such endpoint: "+r.URL.Path)
+		return
+	}
+	path = "/" + path
+
+	switch {
+	case path == "/search" || path == "/search/jql":
+		s.serveSearch(w, r, path == "/search/jql" && s.TokenPaging)
+	case path == "/myself":
+		writeJSON(w, http.StatusOK, map[string]any{"accountId": "jiratest-user", "displayName": "Test User"})
+	case strings.HasPrefix(path, "/issue/") && strings.HasSuffix(path, "/transitions"):
+		s.serveTransitions(w, r, body, strings.TrimSuffix(strings.TrimPrefix(path, "/issue/"), "/transitions"))
+	case strings.HasPrefix(path, "/issue/") && !strings.Contains(strings.TrimPrefix(path, "/issue/"), "/"):
+		s.serveIssue(w, strings.TrimPrefix(path, "/issue/"))
+	default:
+		writeError(w, http.StatusNotFound, "no such endpoint: "+r.URL.Path)
+	}
+}
+
+// serveSearch pages through all canned issues. The JQL is not evaluated.
+func (s *Server) serveSearch(w http.ResponseWriter, r *http.Request, tokenPaging bool) {
+	s.mu.Lock()
+	issues := append([]map[string]any(nil), s.issues...)
+	s.mu.Unlock()
+
+	pageSize := s.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if maxResults, err := strconv.Atoi(r.URL.Query().Get("maxResults")); err == nil && maxResults > 0 {
+		pageSize = min(pageSize, maxResults)
+	}
+
+	start := 0
+	if token := r.URL.Query().Get("nextPageToken"); token != "" {
+		n, err := strconv.Atoi(token)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid nextPageToken")
+			return
+		}
+		start = n
+	} else if n, err := strconv.Atoi(r.URL.Query().Get("startAt")); err == nil {
+		start = n
+	}
+	start = min(max(start, 0), len(issues))
+	end := min(start+pageSize, len(issues))
+
+	page := map[string]any{"issues": issues[start:end]}
+	if tokenPaging {
+		page["isLast"] = end >= len(issues)
+		if end < len(issues) {
+			page["nextPageToken"] = strconv.Itoa(end)
+		}
+	} else {
+		page["startAt"] = start
+		page["maxResults"] = pageSize
+		page["total"] = len(issues)
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (s *Server) serveIssue(w http.ResponseWriter, key string) {
+	if issue := s.findIssue(key); issue != nil {
+		writeJSON(w, http.StatusOK, issue)
+		return
+	}
+	writeError(w, http.StatusNotFound, "Issue does not exist or you do not have permission to see it.")
+}
+
+func (s *Server) serveTransitions(w http.ResponseWriter, r *http.Request, body []byte, key string) {
+	if s.findIssue(key) == nil {
+		writeError(w, http.StatusNotFound, "Issue does not exist or you do not have permission to see it.")
+		return
+	}
+	s.mu.Lock()
+	available := s.transitions[key]
+	s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		list := make([]map[string]any, len(available))
+		for i, t := range available {
+			list[i] = map[string]any{"id": t.ID, "name": t.Name}
+			if t.To != "" {
+				list[i]["to"] = map[string]any{"name": t.To}
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"transitions": list})
+
+	case http.MethodPost:
+		var req struct {
+			Transition struct {
+				ID string `json:"id"`
+			} `json:"transition"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		for _, t := range available {
+			if t.ID == req.Transition.ID {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Transition id '%s' is not valid for this issue.", req.Transition.ID))
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// findIssue returns the canned issue with the given key, or nil.
+func (s *Server) findIssue(key string) map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, issue := range s.issues {
+		if issue["key"] == key {
+			return issue
+		}
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a Jira-style error response.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{"errorMessages": []string{message}, "errors": map[string]string{}})
+}
@@ -0,0 +1,155 @@
+package jiratest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/jira"
+	"github.com/steveyegge/beads/internal/jira/jiratest"
+)
+
+func newClient(t *testing.T, srv *jiratest.Server) *jira.Client {
+	t.Helper()
+	client, err := jira.NewClient(jira.Config{
+		URL:      srv.URL,
+		Project:  "PROJ",
+		Username: "user",
+		APIToken: "token",
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func addIssues(srv *jiratest.Server, n int) {
+	for i := 1; i <= n; i++ {
+		srv.AddIssue(jiratest.Issue(fmt.Sprintf("PROJ-%d", i), fmt.Sprintf("Issue %d", i)))
+	}
+}
+
+func TestServer_PaginatedSearch(t *testing.T) {
+	srv := jiratest.NewServer(t)
+	srv.PageSize = 100
+	addIssues(srv, 250)
+
+	issues, err := newClient(t, srv).SearchIssues(context.Background(), "", "all")
+	if err != nil {
+		t.Fatalf("SearchIssues() error = %v", err)
+	}
+	if len(issues) != 250 {
+		t.Fatalf("got %d issues, want 250", len(issues))
+	}
+	for i, issue := range issues {
+		if want := fmt.Sprintf("PROJ-%d", i+1); issue.Key != want {
+			t.Fatalf("issues[%d].Key = %q, want %q", i, issue.Key, want)
+		}
+	}
+
+	var offsets []string
+	for _, req := range srv.RequestsTo("/search/jql") {
+		offsets = append(offsets, req.Query.Get("startAt"))
+		if req.Header.Get("Authorization") == "" {
+			t.Error("search request missing Authorization header")
+		}
+	}
+	sort.Strings(offsets)
+	if fmt.Sprint(offsets) != "[0 100 200]" {
+		t.Errorf("requested offsets = %v, want [0 100 200]", offsets)
+	}
+}
+
+func TestServer_TokenPaging(t *testing.T) {
+	srv := jiratest.NewServer(t)
+	srv.PageSize = 2
+	srv.TokenPaging = true
+	addIssues(srv, 5)
+
+	issues, err := newClient(t, srv).SearchIssues(context.Background(), "", "all")
+	if err != nil {
+		t.Fatalf("SearchIssues() error = %v", err)
+	}
+	if len(issues) != 5 {
+		t.Errorf("got %d issues, want 5", len(issues))
+	}
+	if got := len(srv.Requests()); got != 3 {
+		t.Errorf("got %d requests, want 3", got)
+	}
+}
+
+func TestServer_GetIssue(t *testing.T) {
+	srv := jiratest.NewServer(t)
+	addIssues(srv, 1)
+	client := newClient(t, srv)
+
+	issue, err := client.GetIssue(context.Background(), "PROJ-1")
+	if err != nil {
+		t.Fatalf("GetIssue() error = %v", err)
+	}
+	if issue.Fields.Summary != "Issue 1" {
+		t.Errorf("Summary = %q, want Issue 1", issue.Fields.Summary)
+	}
+
+	if _, err := client.GetIssue(context.Background(), "PROJ-404"); !errors.Is(err, jira.ErrNotFound) {
+		t.Errorf("GetIssue() missing issue error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestServer_Transitions(t *testing.T) {
+	srv := jiratest.NewServer(t)
+	addIssues(srv, 1)
+	srv.SetTransitions("PROJ-1", jiratest.Transition{ID: "11", Name: "Start Progress", To: "In Progress"}, jiratest.Transition{ID: "31", Name: "Done"})
+	client := newClient(t, srv)
+
+	available, err := client.GetTransitions(context.Background(), "PROJ-1")
+	if err != nil {
+		t.Fatalf("GetTransitions() error = %v", err)
+	}
+	if len(available) != 2 || available[0].To == nil || available[0].To.Name != "In Progress" || available[1].To != nil {
+		t.Errorf("GetTransitions() = %+v, want target status only on the first", available)
+	}
+
+	if err := client.TransitionIssue(context.Background(), "PROJ-1", "done"); err != nil {
+		t.Fatalf("TransitionIssue() error = %v", err)
+	}
+
+	// The first request is the GetTransitions call above
+	reqs := srv.RequestsTo("/issue/PROJ-1/transitions")
+	if len(reqs) != 3 {
+		t.Fatalf("got %d transition requests, want 3", len(reqs))
+	}
+	reqs = reqs[1:]
+	if reqs[0].Method != http.MethodGet || reqs[1].Method != http.MethodPost {
+		t.Fatalf("transition requests = %+v, want GET then POST", reqs)
+	}
+	var body struct {
+		Transition struct {
+			ID string `json:"id"`
+		} `json:"transition"`
+	}
+	if err := json.Unmarshal(reqs[1].Body, &body); err != nil || body.Transition.ID != "31" {
+		t.Errorf("POST body = %s, want transition id 31", reqs[1].Body)
+	}
+}
+
+func TestServer_FailNext(t *testing.T) {
+	srv := jiratest.NewServer(t)
+	addIssues(srv, 1)
+	srv.FailNext(http.StatusServiceUnavailable, `{"errorMessages": ["try again"]}`)
+
+	client, err := jira.NewClient(jira.Config{URL: srv.URL, APIToken: "token", RetryBaseDelay: 1})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, err := client.GetIssue(context.Background(), "PROJ-1"); err != nil {
+		t.Fatalf("GetIssue() error = %v, want retry to succeed", err)
+	}
+	if got := len(srv.Requests()); got != 2 {
+		t.Errorf("got %d requests, want 2 (failure then retry)", got)
+	}
+}
@@ -0,0 +1,107 @@
+package jira
+
+import (
+	"strings"
+	"time"
+)
+
+// JQLBuilder builds a JQL query from structured filters, quoting every value
+// so user input cannot break the query. Filters given empty values are
+// omitted. The zero value is ready to use:
+//
+//	jql := new(JQLBuilder).Project("PROJ").Status("To Do", "In Progress").OrderBy("created", true).String()
+type JQLBuilder struct {
+	clauses  []string
+	order    []string
+	location *time.Location
+}
+
+// In sets the time zone of the Jira user's profile (see Config.Location), in
+// which JQL interprets times. Time filters added afterwards are converted to
+// it; by default they are formatted in UTC.
+func (b *JQLBuilder) In(loc *time.Location) *JQLBuilder {
+	b.location = loc
+	return b
+}
+
+// Project restricts the query to a project key.
+func (b *JQLBuilder) Project(key string) *JQLBuilder {
+	if key != "" {
+		b.clauses = append(b.clauses, "project = "+quoteJQLValue(key))
+	}
+	return b
+}
+
+// Status restricts the query to issues in any of the given statuses.
+func (b *JQLBuilder) Status(names ...string) *JQLBuilder {
+	return b.in("status", names)
+}
+
+// AssigneeAccountID restricts the query to issues assigned to a user,
+// identified by accountId on Cloud or username on Server/DC.
+func (b *JQLBuilder) AssigneeAccountID(id string) *JQLBuilder {
+	if id != "" {
+		b.clauses = append(b.clauses, "assignee = "+quoteJQLValue(id))
+	}
+	return b
+}
+
+// UpdatedAfter restricts the query to issues updated at or after t, to the
+// minute, matching SearchOptions.UpdatedSince so an issue updated exactly at
+// a sync cursor is not skipped. The zero time is ignored.
+func (b *JQLBuilder) UpdatedAfter(t time.Time) *JQLBuilder {
+	if !t.IsZero() {
+		b.clauses = append(b.clauses, "updated >= "+quoteJQLValue(formatJQLTime(t, b.location)))
+	}
+	return b
+}
+
+// Labels restricts the query to issues with any of the given labels.
+func (b *JQLBuilder) Labels(labels ...string) *JQLBuilder {
+	return b.in("labels", labels)
+}
+
+// OrderBy adds a sort field. Repeated calls add secondary sort fields.
+func (b *JQLBuilder) OrderBy(field string, asc bool) *JQLBuilder {
+	if field == "" {
+		return b
+	}
+	direction := "DESC"
+	if asc {
+		direction = "ASC"
+	}
+	b.order = append(b.order, field+" "+direction)
+	return b
+}
+
+// String returns the query: the filters joined with AND, followed by any
+// ORDER BY clause.
+func (b *JQLBuilder) String() string {
+	jql := strings.Join(b.clauses, " AND ")
+	if len(b.order) > 0 {
+		if jql != "" {
+			jql += " "
+		}
+		jql += "ORDER BY " + strings.Join(b.order, ", ")
+	}
+	return jql
+}
+
+// in adds "field = value" for one value or "field IN (...)" for several,
+// skipping empty values.
+func (b *JQLBuilder) in(field string, values []string) *JQLBuilder {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != "" {
+			quoted = append(quoted, quoteJQLValue(v))
+		}
+	}
+	switch len(quoted) {
+	case 0:
+	case 1:
+		b.clauses = append(b.clauses, field+" = "+quoted[0])
+	default:
+		b.clauses = append(b.clauses, field+" IN ("+strings.Join(quoted, ", ")+")")
+	}
+	return b
+}
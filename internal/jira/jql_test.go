@@ -0,0 +1,80 @@
+package jira
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJQLBuilder(t *testing.T) {
+	since := time.Date(2024, 3, 10, 15, 45, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		build func(b *JQLBuilder)
+		want  string
+	}{
+		{
+			name:  "empty",
+			build: func(b *JQLBuilder) {},
+			want:  "",
+		},
+		{
+			name:  "project only",
+			build: func(b *JQLBuilder) { b.Project("PROJ") },
+			want:  `project = "PROJ"`,
+		},
+		{
+			name: "all filters",
+			build: func(b *JQLBuilder) {
+				b.Project("PROJ").
+					Status("To Do", "In Progress").
+					AssigneeAccountID("5b10ac8d82e05b22cc7d4ef5").
+					UpdatedAfter(since).
+					Labels("backend").
+					OrderBy("priority", false).
+					OrderBy("created", true)
+			},
+			want: `project = "PROJ" AND status IN ("To Do", "In Progress") AND assignee = "5b10ac8d82e05b22cc7d4ef5"` +
+				` AND updated >= "2024-03-10 15:45" AND labels = "backend" ORDER BY priority DESC, created ASC`,
+		},
+		{
+			name: "time in user's zone",
+			build: func(b *JQLBuilder) {
+				b.In(time.FixedZone("EST", -5*60*60)).UpdatedAfter(since)
+			},
+			want: `updated >= "2024-03-10 10:45"`,
+		},
+		{
+			name:  "time defaults to UTC",
+			build: func(b *JQLBuilder) { b.UpdatedAfter(since.In(time.FixedZone("CET", 60*60))) },
+			want:  `updated >= "2024-03-10 15:45"`,
+		},
+		{
+			name: "empty filters omitted",
+			build: func(b *JQLBuilder) {
+				b.Project("").Status().AssigneeAccountID("").UpdatedAfter(time.Time{}).Labels("", "ui").OrderBy("", true)
+			},
+			want: `labels = "ui"`,
+		},
+		{
+			name:  "order without filters",
+			build: func(b *JQLBuilder) { b.OrderBy("updated", false) },
+			want:  "ORDER BY updated DESC",
+		},
+		{
+			name:  "values escaped",
+			build: func(b *JQLBuilder) { b.Project("AND").Labels(`say "hi"`, `back\slash`) },
+			want:  `project = "AND" AND labels IN ("say \"hi\"", "back\\slash")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b JQLBuilder
+			tt.build(&b)
+			if got := b.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package jira
+
+import "strconv"
+
+// MappingDecision records how a single Jira issue's status, type, and
+// priority would be mapped, without performing a conversion.
+type MappingDecision struct {
+	IssueKey string
+	Status   FieldMapping
+	Type     FieldMapping
+	Priority FieldMapping
+}
+
+// FieldMapping records the original Jira value and the bd value chosen for it.
+type FieldMapping struct {
+	JiraValue   string // Original Jira value (empty if the field was absent)
+	BeadsValue  string // Mapped bd value
+	UsedDefault bool   // True if no mapping matched and the fallback was used
+}
+
+// Preview reports the status, type, and priority mapping decisions for each
+// issue without converting them or generating IDs. Use it to find Jira values
+// that need a custom mapping entry before running an import.
+func (c *Converter) Preview(jiraIssues []*JiraIssue) ([]MappingDecision, error) {
+	decisions := make([]MappingDecision, 0, len(jiraIssues))
+
+	for _, jira := range jiraIssues {
+		decision := MappingDecision{IssueKey: jira.Key}
+
+		status, ok := c.resolveStatus(jira.Fields.Status)
+		decision.Status = FieldMapping{BeadsValue: string(status), UsedDefault: !ok}
+		if jira.Fields.Status != nil {
+			decision.Status.JiraValue = jira.Fields.Status.Name
+		}
+
+		issueType, ok := c.resolveIssueType(jira.Fields.IssueType)
+		decision.Type = FieldMapping{BeadsValue: string(issueType), UsedDefault: !ok}
+		if jira.Fields.IssueType != nil {
+			decision.Type.JiraValue = jira.Fields.IssueType.Name
+		}
+
+		priority, ok := c.resolvePriority(jira.Fields.Priority)
+		decision.Priority = FieldMapping{BeadsValue: strconv.Itoa(priority), UsedDefault: !ok}
+		if jira.Fields.Priority != nil {
+			decision.Priority.JiraValue = jira.Fields.Priority.Name
+		}
+
+		decisions = append(decisions, decision)
+	}
+
+	return decisions, nil
+}
@@ -0,0 +1,56 @@
+package jira
+
+import "testing"
+
+func TestConverter_Preview(t *testing.T) {
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net"})
+
+	decisions, err := converter.Preview([]*JiraIssue{
+		{
+			Key: "PROJ-1",
+			Fields: JiraIssueFields{
+				Status:    &JiraStatus{Name: "In Progress"},
+				IssueType: &JiraIssueType{Name: "Bug"},
+				Priority:  &JiraPriority{Name: "High"},
+			},
+		},
+		{
+			Key: "PROJ-2",
+			Fields: JiraIssueFields{
+				Status:    &JiraStatus{Name: "Awaiting Deploy"},
+				IssueType: &JiraIssueType{Name: "Spike"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Preview() error = %v", err)
+	}
+	if len(decisions) != 2 {
+		t.Fatalf("Preview() returned %d decisions, want 2", len(decisions))
+	}
+
+	mapped := decisions[0]
+	if mapped.IssueKey != "PROJ-1" {
+		t.Errorf("IssueKey = %q, want PROJ-1", mapped.IssueKey)
+	}
+	want := MappingDecision{
+		IssueKey: "PROJ-1",
+		Status:   FieldMapping{JiraValue: "In Progress", BeadsValue: "in_progress"},
+		Type:     FieldMapping{JiraValue: "Bug", BeadsValue: "bug"},
+		Priority: FieldMapping{JiraValue: "High", BeadsValue: "1"},
+	}
+	if mapped != want {
+		t.Errorf("decisions[0] = %+v, want %+v", mapped, want)
+	}
+
+	fallback := decisions[1]
+	if !fallback.Status.UsedDefault || fallback.Status.JiraValue != "Awaiting Deploy" || fallback.Status.BeadsValue != "open" {
+		t.Errorf("unknown status = %+v, want fallback to open", fallback.Status)
+	}
+	if !fallback.Type.UsedDefault || fallback.Type.BeadsValue != "task" {
+		t.Errorf("unknown type = %+v, want fallback to task", fallback.Type)
+	}
+	if !fallback.Priority.UsedDefault || fallback.Priority.JiraValue != "" || fallback.Priority.BeadsValue != "2" {
+		t.Errorf("missing priority = %+v, want fallback to 2", fallback.Priority)
+	}
+}
@@ -0,0 +1,65 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// JiraProject represents a Jira project visible to the authenticated user.
+type JiraProject struct {
+	ID             string `json:"id"`
+	Key            string `json:"key"`
+	Name           string `json:"name"`
+	ProjectTypeKey string `json:"projectTypeKey"` // software, business, or service_desk
+}
+
+// projectSearchResponse is a page of the project search API.
+type projectSearchResponse struct {
+	StartAt    int           `json:"startAt"`
+	MaxResults int           `json:"maxResults"`
+	Total      int           `json:"total"`
+	IsLast     bool          `json:"isLast"`
+	Values     []JiraProject `json:"values"`
+}
+
+// ListProjects returns every project the credentials can see. It is also a
+// cheap way to confirm credentials and permissions before an import.
+func (c *Client) ListProjects(ctx context.Context) ([]JiraProject, error) {
+	var allProjects []JiraProject
+	startAt := 0
+	maxResults := 50
+
+	for {
+		endpoint := c.apiPath(fmt.Sprintf("/project/search?startAt=%d&maxResults=%d", startAt, maxResults))
+
+		resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, c.handleAPIError(resp.StatusCode, body)
+		}
+
+		var result projectSearchResponse
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("decoding response: %w", err)
+		}
+		resp.Body.Close()
+
+		allProjects = append(allProjects, result.Values...)
+
+		startAt += len(result.Values)
+		if result.IsLast || startAt >= result.Total || len(result.Values) == 0 {
+			break
+		}
+	}
+
+	return allProjects, nil
+}
@@ -0,0 +1,63 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestListProjects_Paginated(t *testing.T) {
+	pages := map[string]string{
+		"0": `{"startAt": 0, "maxResults": 2, "total": 3, "isLast": false, "values": [
+			{"id": "10000", "key": "PROJ", "name": "Project", "projectTypeKey": "software"},
+			{"id": "10001", "key": "OPS", "name": "Operations", "projectTypeKey": "service_desk"}
+		]}`,
+		"2": `{"startAt": 2, "maxResults": 2, "total": 3, "isLast": true, "values": [
+			{"id": "10002", "key": "MKT", "name": "Marketing", "projectTypeKey": "business"}
+		]}`,
+	}
+
+	var requested []string
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/rest/api/3/project/search" {
+			t.Errorf("unexpected path %q", req.URL.Path)
+		}
+		startAt := req.URL.Query().Get("startAt")
+		requested = append(requested, startAt)
+		body, ok := pages[startAt]
+		if !ok {
+			return jsonResponse(http.StatusBadRequest, `{"errorMessages": ["bad startAt"]}`), nil
+		}
+		return jsonResponse(http.StatusOK, body), nil
+	})
+
+	projects, err := client.ListProjects(context.Background())
+	if err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+	if len(requested) != 2 {
+		t.Errorf("requests = %v, want 2 pages", requested)
+	}
+	if len(projects) != 3 {
+		t.Fatalf("ListProjects() returned %d projects, want 3", len(projects))
+	}
+	if p := projects[1]; p.Key != "OPS" || p.Name != "Operations" || p.ProjectTypeKey != "service_desk" {
+		t.Errorf("projects[1] = %+v", p)
+	}
+	if projects[2].Key != "MKT" {
+		t.Errorf("projects[2].Key = %q, want MKT", projects[2].Key)
+	}
+}
+
+func TestListProjects_Unauthorized(t *testing.T) {
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusUnauthorized, ``), nil
+	})
+
+	_, err := client.ListProjects(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Kind != ErrorKindUnauthorized {
+		t.Errorf("ListProjects() error = %v, want unauthorized APIError", err)
+	}
+}
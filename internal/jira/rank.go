@@ -0,0 +1,25 @@
+package jira
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SortByRank sorts issues into Jira backlog order by Issue.Rank. LexoRank
+// values (e.g. "0|hzzzzz:") order correctly as plain strings. Issues without
+// a rank sort last; ties keep their original order.
+func SortByRank(issues []*types.Issue) {
+	slices.SortStableFunc(issues, func(a, b *types.Issue) int {
+		switch {
+		case a.Rank == b.Rank:
+			return 0
+		case a.Rank == "":
+			return 1
+		case b.Rank == "":
+			return -1
+		}
+		return strings.Compare(a.Rank, b.Rank)
+	})
+}
@@ -0,0 +1,53 @@
+package jira
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestConverter_RankField(t *testing.T) {
+	raw := `[
+		{"key": "PROJ-1", "fields": {"summary": "Third", "customfield_10019": "0|i0001r:"}},
+		{"key": "PROJ-2", "fields": {"summary": "Unranked"}},
+		{"key": "PROJ-3", "fields": {"summary": "First", "customfield_10019": "0|hzzzzz:"}},
+		{"key": "PROJ-4", "fields": {"summary": "Second", "customfield_10019": "0|i00007:"}}
+	]`
+	var jiraIssues []*JiraIssue
+	if err := json.Unmarshal([]byte(raw), &jiraIssues); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net", RankFieldID: "customfield_10019"})
+	issues, err := converter.Convert(jiraIssues)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if issues[0].Rank != "0|i0001r:" || issues[1].Rank != "" {
+		t.Errorf("Rank = %q, %q; want 0|i0001r: and empty", issues[0].Rank, issues[1].Rank)
+	}
+
+	SortByRank(issues)
+	var titles []string
+	for _, issue := range issues {
+		titles = append(titles, issue.Title)
+	}
+	if want := []string{"First", "Second", "Third", "Unranked"}; !reflect.DeepEqual(titles, want) {
+		t.Errorf("sorted titles = %v, want %v", titles, want)
+	}
+}
+
+func TestSortByRank_UnrankedKeepOrder(t *testing.T) {
+	issues := []*types.Issue{{ID: "a"}, {ID: "b", Rank: "0|b"}, {ID: "c"}, {ID: "d", Rank: "0|a"}}
+	SortByRank(issues)
+
+	var ids []string
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	if want := []string{"d", "b", "a", "c"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("sorted IDs = %v, want %v", ids, want)
+	}
+}
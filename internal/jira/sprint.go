@@ -0,0 +1,123 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// JiraSprint represents an agile sprint.
+type JiraSprint struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	State   string `json:"state"` // active, closed, or future
+	BoardID int    `json:"originBoardId"`
+}
+
+// GetIssueSprint fetches the sprint an issue belongs to via the Agile API.
+// Returns the active sprint if the issue is in one, otherwise the most
+// recently closed sprint. Returns nil if the issue has never been in a sprint.
+func (c *Client) GetIssueSprint(ctx context.Context, issueKey string) (*JiraSprint, error) {
+	endpoint := fmt.Sprintf("/rest/agile/1.0/issue/%s?fields=sprint,closedSprints", url.PathEscape(issueKey))
+
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, c.handleAPIError(resp.StatusCode, body)
+	}
+
+	var result struct {
+		Fields struct {
+			Sprint        *JiraSprint   `json:"sprint"`
+			ClosedSprints []*JiraSprint `json:"closedSprints"`
+		} `json:"fields"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	if result.Fields.Sprint != nil {
+		return result.Fields.Sprint, nil
+	}
+	return currentSprint(result.Fields.ClosedSprints), nil
+}
+
+// parseSprintField decodes a sprint custom field value. Jira Cloud sends an
+// array of sprint objects; older Server/DC versions send an array of strings
+// like "com.atlassian.greenhopper.service.sprint.Sprint@1a2b[id=1,state=ACTIVE,name=Sprint 1,...]".
+func parseSprintField(raw json.RawMessage) []*JiraSprint {
+	var sprints []*JiraSprint
+	if err := json.Unmarshal(raw, &sprints); err == nil {
+		return sprints
+	}
+
+	var legacy []string
+	if err := json.Unmarshal(raw, &legacy); err != nil {
+		return nil
+	}
+	for _, s := range legacy {
+		if sprint := parseLegacySprint(s); sprint != nil {
+			sprints = append(sprints, sprint)
+		}
+	}
+	return sprints
+}
+
+var legacySprintAttrsRe = regexp.MustCompile(`\[(.*)\]$`)
+
+// parseLegacySprint parses the Server/DC sprint string representation.
+func parseLegacySprint(s string) *JiraSprint {
+	matches := legacySprintAttrsRe.FindStringSubmatch(s)
+	if len(matches) != 2 {
+		return nil
+	}
+
+	sprint := &JiraSprint{}
+	for _, attr := range strings.Split(matches[1], ",") {
+		key, value, ok := strings.Cut(attr, "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "id":
+			sprint.ID, _ = strconv.Atoi(value)
+		case "rapidViewId":
+			sprint.BoardID, _ = strconv.Atoi(value)
+		case "state":
+			sprint.State = strings.ToLower(value)
+		case "name":
+			sprint.Name = value
+		}
+	}
+	if sprint.Name == "" {
+		return nil
+	}
+	return sprint
+}
+
+// currentSprint picks the sprint that best describes where an issue is now:
+// the active sprint if any, otherwise the last one listed.
+func currentSprint(sprints []*JiraSprint) *JiraSprint {
+	for _, s := range sprints {
+		if s != nil && strings.EqualFold(s.State, "active") {
+			return s
+		}
+	}
+	for i := len(sprints) - 1; i >= 0; i-- {
+		if sprints[i] != nil {
+			return sprints[i]
+		}
+	}
+	return nil
+}
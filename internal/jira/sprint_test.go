@@ -0,0 +1,128 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestParseSprintField(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       string
+		wantName  string
+		wantState string
+	}{
+		{
+			name:      "cloud objects with active sprint",
+			raw:       `[{"id": 1, "name": "Sprint 1", "state": "closed"}, {"id": 2, "name": "Sprint 2", "state": "active"}]`,
+			wantName:  "Sprint 2",
+			wantState: "active",
+		},
+		{
+			name:      "cloud objects all closed",
+			raw:       `[{"id": 1, "name": "Sprint 1", "state": "closed"}, {"id": 2, "name": "Sprint 2", "state": "closed"}]`,
+			wantName:  "Sprint 2",
+			wantState: "closed",
+		},
+		{
+			name:      "server legacy strings",
+			raw:       `["com.atlassian.greenhopper.service.sprint.Sprint@1a2b[id=7,rapidViewId=3,state=ACTIVE,name=Team Sprint 7,startDate=2024-01-01T00:00:00.000Z]"]`,
+			wantName:  "Team Sprint 7",
+			wantState: "active",
+		},
+		{
+			name: "null",
+			raw:  `null`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sprint := currentSprint(parseSprintField(json.RawMessage(tt.raw)))
+			if tt.wantName == "" {
+				if sprint != nil {
+					t.Errorf("currentSprint() = %+v, want nil", sprint)
+				}
+				return
+			}
+			if sprint == nil {
+				t.Fatal("currentSprint() = nil")
+			}
+			if sprint.Name != tt.wantName || sprint.State != tt.wantState {
+				t.Errorf("currentSprint() = %q (%s), want %q (%s)", sprint.Name, sprint.State, tt.wantName, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestConverter_Sprint(t *testing.T) {
+	payload := `[
+		{"key": "PROJ-1", "fields": {"summary": "In sprint",
+			"customfield_10020": [{"id": 5, "name": "Sprint 5", "state": "active", "originBoardId": 2}]}},
+		{"key": "PROJ-2", "fields": {"summary": "No sprint", "customfield_10020": null}},
+		{"key": "PROJ-3", "fields": {"summary": "Agile API", "sprint": {"id": 6, "name": "Sprint 6", "state": "future"}}}
+	]`
+	var jiraIssues []*JiraIssue
+	if err := json.Unmarshal([]byte(payload), &jiraIssues); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	converter := NewConverter(ConverterConfig{
+		JiraURL:       "https://test.atlassian.net",
+		SprintFieldID: "customfield_10020",
+	})
+	issues, err := converter.Convert(jiraIssues)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	if issues[0].Sprint != "Sprint 5" || issues[0].SprintState != "active" {
+		t.Errorf("PROJ-1 sprint = %q (%s), want Sprint 5 (active)", issues[0].Sprint, issues[0].SprintState)
+	}
+	if issues[1].Sprint != "" || issues[1].SprintState != "" {
+		t.Errorf("PROJ-2 sprint = %q (%s), want empty", issues[1].Sprint, issues[1].SprintState)
+	}
+	if issues[2].Sprint != "Sprint 6" || issues[2].SprintState != "future" {
+		t.Errorf("PROJ-3 sprint = %q (%s), want Sprint 6 (future)", issues[2].Sprint, issues[2].SprintState)
+	}
+}
+
+func TestGetIssueSprint(t *testing.T) {
+	responses := map[string]string{
+		"/rest/agile/1.0/issue/PROJ-1": `{"fields": {"sprint": {"id": 3, "name": "Sprint 3", "state": "active"},
+			"closedSprints": [{"id": 2, "name": "Sprint 2", "state": "closed"}]}}`,
+		"/rest/agile/1.0/issue/PROJ-2": `{"fields": {"closedSprints": [{"id": 1, "name": "Sprint 1", "state": "closed"},
+			{"id": 2, "name": "Sprint 2", "state": "closed"}]}}`,
+		"/rest/agile/1.0/issue/PROJ-3": `{"fields": {}}`,
+	}
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if got := req.URL.Query().Get("fields"); got != "sprint,closedSprints" {
+			t.Errorf("fields = %q, want sprint,closedSprints", got)
+		}
+		return jsonResponse(http.StatusOK, responses[req.URL.Path]), nil
+	})
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"PROJ-1", "Sprint 3"},
+		{"PROJ-2", "Sprint 2"},
+		{"PROJ-3", ""},
+	}
+	for _, tt := range tests {
+		sprint, err := client.GetIssueSprint(context.Background(), tt.key)
+		if err != nil {
+			t.Fatalf("GetIssueSprint(%s) error = %v", tt.key, err)
+		}
+		got := ""
+		if sprint != nil {
+			got = sprint.Name
+		}
+		if got != tt.want {
+			t.Errorf("GetIssueSprint(%s) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package jira
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// requestThrottle spaces requests at least interval apart. Callers reserve
+// the next free slot under a lock, so concurrent requests share one budget.
+// A nil throttle never waits.
+type requestThrottle struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time // Earliest time the next request may start
+}
+
+// newRequestThrottle returns a throttle for interval, or nil if interval is
+// not positive.
+func newRequestThrottle(interval time.Duration) *requestThrottle {
+	if interval <= 0 {
+		return nil
+	}
+	return &requestThrottle{interval: interval}
+}
+
+// wait blocks until the caller's reserved slot arrives or ctx is done. A
+// caller that gives up returns its slot if no later caller has reserved one
+// behind it, so cancelled requests do not delay the ones that follow.
+func (t *requestThrottle) wait(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	slot := time.Now()
+	if t.next.After(slot) {
+		slot = t.next
+	}
+	reserved := slot.Add(t.interval)
+	t.next = reserved
+	t.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		t.mu.Lock()
+		if t.next.Equal(reserved) {
+			t.next = slot
+		}
+		t.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,72 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_MinRequestInterval(t *testing.T) {
+	const (
+		requests = 5
+		interval = 20 * time.Millisecond
+	)
+
+	client, err := NewClient(Config{
+		URL:                "https://jira.example.com",
+		APIToken:           "token",
+		MinRequestInterval: interval,
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusOK, `{}`), nil
+		})},
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	// Concurrent callers share the same budget
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.Ping(context.Background()); err != nil {
+				t.Errorf("Ping() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The first request goes immediately; each later one waits an interval
+	if elapsed, want := time.Since(start), (requests-1)*interval; elapsed < want {
+		t.Errorf("%d requests took %v, want at least %v", requests, elapsed, want)
+	}
+}
+
+func TestRequestThrottle_ContextCancel(t *testing.T) {
+	throttle := newRequestThrottle(time.Hour)
+	if err := throttle.wait(context.Background()); err != nil {
+		t.Fatalf("first wait() error = %v", err)
+	}
+
+	reserved := throttle.next
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := throttle.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("wait() error = %v, want deadline exceeded", err)
+	}
+
+	// The cancelled caller gives its slot back
+	if !throttle.next.Equal(reserved) {
+		t.Errorf("next = %v after cancellation, want %v", throttle.next, reserved)
+	}
+
+	if err := (*requestThrottle)(nil).wait(context.Background()); err != nil {
+		t.Errorf("nil throttle wait() error = %v", err)
+	}
+}
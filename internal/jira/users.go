@@ -0,0 +1,142 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Limits for each /user/bulk request: the number of account IDs, and the
+// encoded length of their query parameters, keeping the URL well within
+// the limits of Jira and any proxies in front of it.
+const (
+	userBulkBatchSize   = 50
+	userBulkMaxQueryLen = 2000
+)
+
+// ResolveUsers looks up users by account ID, for example to map Cloud
+// account IDs to emails and display names. Duplicate IDs are looked up once
+// and requests are batched to respect URL length limits. The result is keyed
+// by account ID; IDs Jira does not return, such as deleted users, are
+// omitted.
+func (c *Client) ResolveUsers(ctx context.Context, accountIDs []string) (map[string]*JiraUser, error) {
+	users := make(map[string]*JiraUser, len(accountIDs))
+	for _, batch := range userBulkBatches(accountIDs) {
+		if err := c.fetchUserBatch(ctx, batch, users); err != nil {
+			return nil, err
+		}
+	}
+	return users, nil
+}
+
+// userBulkBatches splits account IDs into batches within the /user/bulk
+// limits, dropping empty and duplicate IDs.
+func userBulkBatches(accountIDs []string) [][]string {
+	var batches [][]string
+	var batch []string
+	queryLen := 0
+	seen := make(map[string]bool, len(accountIDs))
+
+	for _, id := range accountIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		paramLen := len("accountId=") + len(url.QueryEscape(id)) + 1
+		if len(batch) > 0 && (len(batch) == userBulkBatchSize || queryLen+paramLen > userBulkMaxQueryLen) {
+			batches = append(batches, batch)
+			batch, queryLen = nil, 0
+		}
+		batch = append(batch, id)
+		queryLen += paramLen
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// fetchUserBatch fetches one batch of account IDs into users, following
+// pages until Jira reports the last one.
+func (c *Client) fetchUserBatch(ctx context.Context, accountIDs []string, users map[string]*JiraUser) error {
+	params := url.Values{}
+	params.Set("maxResults", strconv.Itoa(userBulkBatchSize))
+	params["accountId"] = accountIDs
+
+	for startAt := 0; ; {
+		params.Set("startAt", strconv.Itoa(startAt))
+		resp, err := c.doRequest(ctx, "GET", c.apiPath("/user/bulk?"+params.Encode()), nil)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return c.handleAPIError(resp.StatusCode, body)
+		}
+
+		var page struct {
+			IsLast bool        `json:"isLast"`
+			Values []*JiraUser `json:"values"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("decoding response: %w", err)
+		}
+
+		for _, user := range page.Values {
+			if user == nil {
+				continue
+			}
+			users[user.AccountID] = user
+		}
+
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 {
+			return nil
+		}
+	}
+}
+
+// resolveAccountNames fills in the display name and email of assignees and
+// reporters that carry only an account ID, as GDPR-strict Cloud sites
+// return them, using one batched lookup for all issues.
+func (c *Client) resolveAccountNames(ctx context.Context, issues []*JiraIssue) error {
+	var pending []*JiraUser
+	var ids []string
+	seen := make(map[string]bool)
+	for _, issue := range issues {
+		for _, user := range []*JiraUser{issue.Fields.Assignee, issue.Fields.Reporter} {
+			if user == nil || user.AccountID == "" || user.DisplayName != "" || user.Name != "" || user.EmailAddress != "" {
+				continue
+			}
+			pending = append(pending, user)
+			if !seen[user.AccountID] {
+				seen[user.AccountID] = true
+				ids = append(ids, user.AccountID)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	users, err := c.ResolveUsers(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("resolving account IDs: %w", err)
+	}
+	for _, user := range pending {
+		if resolved, ok := users[user.AccountID]; ok {
+			user.DisplayName = resolved.DisplayName
+			user.EmailAddress = resolved.EmailAddress
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestSearchIssues_ResolveAccountIDs(t *testing.T) {
+	var bulkIDs []string
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/rest/api/3/search/jql":
+			return jsonResponse(http.StatusOK, `{"startAt": 0, "maxResults": 100, "total": 2, "issues": [
+				{"key": "PROJ-1", "fields": {"summary": "One", "assignee": {"accountId": "a1"}, "reporter": {"accountId": "a2"}}},
+				{"key": "PROJ-2", "fields": {"summary": "Two", "assignee": {"accountId": "a1"}, "reporter": {"accountId": "a3", "displayName": "Known"}}}
+			]}`), nil
+		case "/rest/api/3/user/bulk":
+			bulkIDs = req.URL.Query()["accountId"]
+			return jsonResponse(http.StatusOK, `{"isLast": true, "values": [
+				{"accountId": "a1", "displayName": "Alice Smith", "emailAddress": "alice@example.com"},
+				{"accountId": "a2", "displayName": "Bob Jones"}
+			]}`), nil
+		}
+		t.Errorf("unexpected request %s", req.URL)
+		return jsonResponse(http.StatusNotFound, ``), nil
+	})
+
+	client, err := NewClient(Config{
+		URL:               "https://example.atlassian.net",
+		Project:           "PROJ",
+		Username:          "user",
+		APIToken:          "token",
+		ResolveAccountIDs: true,
+		HTTPClient:        &http.Client{Transport: transport},
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	issues, err := client.SearchIssues(context.Background(), "", "all")
+	if err != nil {
+		t.Fatalf("SearchIssues() error = %v", err)
+	}
+
+	// Each unresolved account is looked up once; named users are skipped
+	sort.Strings(bulkIDs)
+	if !reflect.DeepEqual(bulkIDs, []string{"a1", "a2"}) {
+		t.Errorf("bulk lookup IDs = %v, want [a1 a2]", bulkIDs)
+	}
+
+	got := []string{
+		issues[0].Fields.Assignee.GetDisplayName(),
+		issues[0].Fields.Reporter.GetDisplayName(),
+		issues[1].Fields.Assignee.GetDisplayName(),
+		issues[1].Fields.Reporter.GetDisplayName(),
+	}
+	want := []string{"Alice Smith", "Bob Jones", "Alice Smith", "Known"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("names = %v, want %v", got, want)
+	}
+	if issues[0].Fields.Assignee.EmailAddress != "alice@example.com" {
+		t.Errorf("EmailAddress = %q, want alice@example.com", issues[0].Fields.Assignee.EmailAddress)
+	}
+}
+
+func TestResolveUsers_Batches(t *testing.T) {
+	ids := make([]string, userBulkBatchSize+5)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("acct-%d", i)
+	}
+	// Duplicates and empty IDs are dropped
+	input := append(append([]string{}, ids...), "acct-0", "acct-3", "")
+
+	var batchSizes []int
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/rest/api/3/user/bulk" {
+			t.Errorf("path = %q, want /rest/api/3/user/bulk", req.URL.Path)
+		}
+		batch := req.URL.Query()["accountId"]
+		batchSizes = append(batchSizes, len(batch))
+		values := make([]string, len(batch))
+		for i, id := range batch {
+			values[i] = fmt.Sprintf(`{"accountId": %q, "displayName": "User %s", "emailAddress": "%s@example.com"}`, id, id, id)
+		}
+		return jsonResponse(http.StatusOK, `{"isLast": true, "values": [`+strings.Join(values, ",")+`]}`), nil
+	})
+
+	users, err := client.ResolveUsers(context.Background(), input)
+	if err != nil {
+		t.Fatalf("ResolveUsers() error = %v", err)
+	}
+	if !reflect.DeepEqual(batchSizes, []int{userBulkBatchSize, 5}) {
+		t.Errorf("batch sizes = %v, want [%d 5]", batchSizes, userBulkBatchSize)
+	}
+	if len(users) != len(ids) {
+		t.Errorf("got %d users, want %d", len(users), len(ids))
+	}
+	if u := users["acct-3"]; u == nil || u.EmailAddress != "acct-3@example.com" {
+		t.Errorf("users[acct-3] = %+v, want acct-3@example.com", u)
+	}
+}
+
+func TestResolveUsers_NullEntries(t *testing.T) {
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusOK, `{"isLast": true, "values": [
+			null,
+			{"accountId": "a1", "displayName": "Alice Smith"}
+		]}`), nil
+	})
+
+	users, err := client.ResolveUsers(context.Background(), []string{"a0", "a1"})
+	if err != nil {
+		t.Fatalf("ResolveUsers() error = %v", err)
+	}
+	if len(users) != 1 || users["a1"].GetDisplayName() != "Alice Smith" {
+		t.Errorf("ResolveUsers() = %v, want only a1", users)
+	}
+}
+
+func TestUserBulkBatches_URLLength(t *testing.T) {
+	long := strings.Repeat("x", 500)
+	ids := []string{long + "1", long + "2", long + "3", long + "4", long + "5"}
+
+	batches := userBulkBatches(ids)
+	if len(batches) < 2 {
+		t.Fatalf("got %d batches, want the long IDs split", len(batches))
+	}
+	total := 0
+	for _, batch := range batches {
+		queryLen := 0
+		for _, id := range batch {
+			queryLen += len("accountId=") + len(id) + 1
+		}
+		if queryLen > userBulkMaxQueryLen {
+			t.Errorf("batch query length %d exceeds %d", queryLen, userBulkMaxQueryLen)
+		}
+		total += len(batch)
+	}
+	if total != len(ids) {
+		t.Errorf("batched %d IDs, want %d", total, len(ids))
+	}
+}
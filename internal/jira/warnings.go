@@ -0,0 +1,94 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ConversionWarning records a problem that did not stop an issue from
+// converting, such as an unmapped status or an unparseable custom field.
+type ConversionWarning struct {
+	IssueKey string
+	Field    string // Jira field the warning concerns, e.g. "priority" or "parent"
+	Message  string
+}
+
+// String returns a human-readable form of the warning.
+func (w ConversionWarning) String() string {
+	return fmt.Sprintf("%s: %s: %s", w.IssueKey, w.Field, w.Message)
+}
+
+// ConvertWithWarnings is Convert that also reports, per issue, values that
+// fell back to a default mapping, custom fields that could not be parsed,
+// and references to issues outside the batch.
+func (c *Converter) ConvertWithWarnings(jiraIssues []*JiraIssue) ([]*types.Issue, []ConversionWarning, error) {
+	result, err := c.ConvertWithDependencies(jiraIssues)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var warnings []ConversionWarning
+	for _, jira := range jiraIssues {
+		if !c.isLeftOut(jira.Key) {
+			warnings = append(warnings, c.mappingWarnings(jira)...)
+		}
+	}
+	for _, ref := range result.Unresolved {
+		field := "issuelinks"
+		if ref.LinkType == ParentLinkType {
+			field = "parent"
+		}
+		warnings = append(warnings, ConversionWarning{
+			IssueKey: ref.IssueKey,
+			Field:    field,
+			Message:  fmt.Sprintf("%s not in the converted batch; %q link dropped", ref.TargetKey, ref.LinkType),
+		})
+	}
+	return result.Issues, warnings, nil
+}
+
+// mappingWarnings reports the fields of jira that could not be mapped or parsed.
+func (c *Converter) mappingWarnings(jira *JiraIssue) []ConversionWarning {
+	var warnings []ConversionWarning
+	add := func(field, format string, args ...any) {
+		warnings = append(warnings, ConversionWarning{IssueKey: jira.Key, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if status, ok := c.resolveStatus(jira.Fields.Status); !ok && jira.Fields.Status != nil {
+		add("status", "unmapped status %q; using %s", jira.Fields.Status.Name, status)
+	}
+	if issueType, ok := c.resolveIssueType(jira.Fields.IssueType); !ok && jira.Fields.IssueType != nil {
+		add("issuetype", "unmapped issue type %q; using %s", jira.Fields.IssueType.Name, issueType)
+	}
+	if priority, ok := c.resolvePriority(jira.Fields.Priority); !ok && jira.Fields.Priority != nil {
+		add("priority", "unmapped priority %q; using %d", jira.Fields.Priority.Name, priority)
+	}
+	if c.storyPointsField != "" {
+		if raw := jira.Fields.Raw[c.storyPointsField]; !isNumericField(raw) {
+			add(c.storyPointsField, "story points %s are not a number; using 0", raw)
+		}
+	}
+	return warnings
+}
+
+// isNumericField reports whether a raw custom field is absent, null, or
+// holds a number parseFloatField can read.
+func isNumericField(raw json.RawMessage) bool {
+	var f *float64
+	if len(raw) == 0 || json.Unmarshal(raw, &f) == nil {
+		return true
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return false
+	}
+	if s = strings.TrimSpace(s); s == "" {
+		return true
+	}
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
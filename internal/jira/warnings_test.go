@@ -0,0 +1,37 @@
+package jira
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestConverter_ConvertWithWarnings(t *testing.T) {
+	raw := `[
+		{"key": "PROJ-1", "fields": {"summary": "Odd priority", "status": {"name": "To Do"}, "issuetype": {"name": "Bug"}, "priority": {"name": "Blocker++"}}},
+		{"key": "PROJ-2", "fields": {"summary": "Odd points", "priority": {"name": "High"}, "customfield_10016": "lots", "parent": {"key": "OTHER-1"}}},
+		{"key": "PROJ-3", "fields": {"summary": "Clean", "status": {"name": "Done"}, "priority": {"name": "Low"}, "customfield_10016": "3"}}
+	]`
+	var jiraIssues []*JiraIssue
+	if err := json.Unmarshal([]byte(raw), &jiraIssues); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	converter := NewConverter(ConverterConfig{JiraURL: "https://test.atlassian.net", StoryPointsFieldID: "customfield_10016"})
+	issues, warnings, err := converter.ConvertWithWarnings(jiraIssues)
+	if err != nil {
+		t.Fatalf("ConvertWithWarnings() error = %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("got %d issues, want 3", len(issues))
+	}
+
+	want := []ConversionWarning{
+		{"PROJ-1", "priority", `unmapped priority "Blocker++"; using 2`},
+		{"PROJ-2", "customfield_10016", `story points "lots" are not a number; using 0`},
+		{"PROJ-2", "parent", `OTHER-1 not in the converted batch; "parent" link dropped`},
+	}
+	if !reflect.DeepEqual(warnings, want) {
+		t.Errorf("warnings =\n%v\nwant\n%v", warnings, want)
+	}
+}
@@ -0,0 +1,57 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// JiraWatchers holds the users watching an issue.
+type JiraWatchers struct {
+	WatchCount int         `json:"watchCount"`
+	IsWatching bool        `json:"isWatching"` // Whether the authenticated user is watching
+	Watchers   []*JiraUser `json:"watchers"`
+}
+
+// GetWatchers fetches the watchers of an issue. This costs one request per
+// issue, so it is not part of SearchIssues; call it only when needed.
+func (c *Client) GetWatchers(ctx context.Context, issueKey string) (*JiraWatchers, error) {
+	endpoint := c.apiPath(fmt.Sprintf("/issue/%s/watchers", url.PathEscape(issueKey)))
+
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, c.handleAPIError(resp.StatusCode, body)
+	}
+
+	var result JiraWatchers
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// AttachWatchers sets issue.Watchers to the display names of the watchers
+// fetched with GetWatchers. A nil watchers value leaves the issue unchanged.
+func AttachWatchers(issue *types.Issue, watchers *JiraWatchers) {
+	if watchers == nil {
+		return
+	}
+	issue.Watchers = nil
+	for _, u := range watchers.Watchers {
+		if name := u.GetDisplayName(); name != "" {
+			issue.Watchers = append(issue.Watchers, name)
+		}
+	}
+}
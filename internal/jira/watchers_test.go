@@ -0,0 +1,44 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestGetWatchers(t *testing.T) {
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/rest/api/3/issue/PROJ-1/watchers" {
+			t.Errorf("unexpected path %q", req.URL.Path)
+		}
+		return jsonResponse(http.StatusOK, `{
+			"self": "https://jira.example.com/rest/api/3/issue/PROJ-1/watchers",
+			"isWatching": false,
+			"watchCount": 2,
+			"watchers": [
+				{"accountId": "abc", "displayName": "Alice"},
+				{"name": "bob", "emailAddress": "bob@example.com"}
+			]
+		}`), nil
+	})
+
+	watchers, err := client.GetWatchers(context.Background(), "PROJ-1")
+	if err != nil {
+		t.Fatalf("GetWatchers() error = %v", err)
+	}
+	if watchers.WatchCount != 2 || len(watchers.Watchers) != 2 {
+		t.Fatalf("watchers = %+v, want 2 watchers", watchers)
+	}
+	if watchers.Watchers[0].AccountID != "abc" {
+		t.Errorf("Watchers[0].AccountID = %q, want abc", watchers.Watchers[0].AccountID)
+	}
+
+	issue := &types.Issue{}
+	AttachWatchers(issue, watchers)
+	if want := []string{"Alice", "bob"}; !reflect.DeepEqual(issue.Watchers, want) {
+		t.Errorf("Watchers = %v, want %v", issue.Watchers, want)
+	}
+}
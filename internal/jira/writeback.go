@@ -0,0 +1,319 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// CreateIssue creates a Jira issue in the configured project from a bd issue
+// and returns the new Jira key. Type and priority are mapped using
+// DefaultJiraTypeNames and DefaultJiraPriorityNames. Jira places new issues in
+// the workflow's initial status, so any other status is then applied through
+// the transition leading to its DefaultJiraStatusNames entry. If that fails,
+// the key of the created issue is returned along with the error.
+func (c *Client) CreateIssue(ctx context.Context, issue *types.Issue) (string, error) {
+	if c.project == "" {
+		return "", fmt.Errorf("jira project is required to create issues")
+	}
+
+	fields := map[string]any{
+		"project": map[string]string{"key": c.project},
+		"summary": issue.Title,
+	}
+	if issue.Description != "" {
+		fields["description"] = c.richText(issue.Description)
+	}
+	if name, ok := DefaultJiraTypeNames[issue.IssueType]; ok {
+		fields["issuetype"] = map[string]string{"name": name}
+	} else {
+		fields["issuetype"] = map[string]string{"name": DefaultJiraTypeNames[types.TypeTask]}
+	}
+	if name, ok := DefaultJiraPriorityNames[issue.Priority]; ok {
+		fields["priority"] = map[string]string{"name": name}
+	}
+	if len(issue.Labels) > 0 {
+		fields["labels"] = issue.Labels
+	}
+	if issue.AssigneeID != "" || issue.Assignee != "" {
+		assignee, err := c.assigneeField(issue)
+		if err != nil {
+			return "", err
+		}
+		fields["assignee"] = assignee
+	}
+
+	reqBody, err := json.Marshal(map[string]any{"fields": fields})
+	if err != nil {
+		return "", fmt.Errorf("encoding request: %w", err)
+	}
+
+	resp, err := c.doRequest(ctx, "POST", c.apiPath("/issue"), bytes.NewReader(reqBody))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", c.writeError(resp.StatusCode, body)
+	}
+
+	var result struct {
+		ID  string `json:"id"`
+		Key string `json:"key"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("decoding response: %w", err)
+	}
+
+	if name, ok := DefaultJiraStatusNames[issue.Status]; ok && issue.Status != types.StatusOpen {
+		if err := c.transitionToStatus(ctx, result.Key, name); err != nil {
+			return result.Key, fmt.Errorf("created %s but could not set status: %w", result.Key, err)
+		}
+	}
+
+	return result.Key, nil
+}
+
+// UpdateIssue sets the given fields on an existing issue. Keys are Jira field
+// IDs (e.g. "summary", "labels", "customfield_10016"). A plain-text
+// "description" is converted to ADF for API v3, and a string "assignee" is
+// sent as an accountId on Cloud or a username on Server/DC. Field validation
+// errors from Jira are included in the returned error.
+func (c *Client) UpdateIssue(ctx context.Context, key string, fields map[string]any) error {
+	body := make(map[string]any, len(fields))
+	for name, value := range fields {
+		body[name] = value
+	}
+	if desc, ok := body["description"].(string); ok {
+		body["description"] = c.richText(desc)
+	}
+	if assignee, ok := body["assignee"].(string); ok {
+		body["assignee"] = c.userRef(assignee)
+	}
+
+	reqBody, err := json.Marshal(map[string]any{"fields": body})
+	if err != nil {
+		return fmt.Errorf("encoding request: %w", err)
+	}
+
+	endpoint := c.apiPath("/issue/" + url.PathEscape(key))
+	resp, err := c.doRequest(ctx, "PUT", endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return c.writeError(resp.StatusCode, respBody)
+	}
+	return nil
+}
+
+// AddComment posts a plain-text comment to an issue, converted to ADF for API
+// v3, and returns the created comment. A 403 means the user lacks the "Add
+// Comments" permission in the project.
+func (c *Client) AddComment(ctx context.Context, key, body string) (*JiraComment, error) {
+	reqBody, err := json.Marshal(map[string]any{"body": c.richText(body)})
+	if err != nil {
+		return nil, fmt.Errorf("encoding request: %w", err)
+	}
+
+	endpoint := c.apiPath(fmt.Sprintf("/issue/%s/comment", url.PathEscape(key)))
+	resp, err := c.doRequest(ctx, "POST", endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		err := c.writeError(resp.StatusCode, respBody)
+		if resp.StatusCode == http.StatusForbidden {
+			return nil, fmt.Errorf("no permission to comment on %s: %w", key, err)
+		}
+		return nil, err
+	}
+
+	var comment JiraComment
+	if err := json.NewDecoder(resp.Body).Decode(&comment); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return &comment, nil
+}
+
+// JiraTransition is a workflow transition currently available for an issue.
+type JiraTransition struct {
+	ID   string      `json:"id"`
+	Name string      `json:"name"`
+	To   *JiraStatus `json:"to"` // Status the issue moves to
+}
+
+// GetTransitions returns the workflow transitions the authenticated user can
+// apply to an issue in its current status.
+func (c *Client) GetTransitions(ctx context.Context, key string) ([]JiraTransition, error) {
+	endpoint := c.apiPath(fmt.Sprintf("/issue/%s/transitions", url.PathEscape(key)))
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, c.handleAPIError(resp.StatusCode, body)
+	}
+
+	var available struct {
+		Transitions []JiraTransition `json:"transitions"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&available); err != nil {
+		return nil, fmt.Errorf("decoding transitions: %w", err)
+	}
+	return available.Transitions, nil
+}
+
+// TransitionIssue moves an issue through the workflow transition with the
+// given name (matched case-insensitively, e.g. "Done" or "Start Progress").
+// If no available transition matches, the error lists the ones that are.
+func (c *Client) TransitionIssue(ctx context.Context, key, transitionName string) error {
+	available, err := c.GetTransitions(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	transitionID := ""
+	names := make([]string, 0, len(available))
+	for _, t := range available {
+		if strings.EqualFold(t.Name, transitionName) {
+			transitionID = t.ID
+			break
+		}
+		names = append(names, t.Name)
+	}
+	if transitionID == "" {
+		return fmt.Errorf("no transition %q for %s; available: %s", transitionName, key, strings.Join(names, ", "))
+	}
+	return c.postTransition(ctx, key, transitionID)
+}
+
+// transitionToStatus moves an issue into the named status (matched
+// case-insensitively) through whichever available transition leads there.
+func (c *Client) transitionToStatus(ctx context.Context, key, statusName string) error {
+	available, err := c.GetTransitions(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(available))
+	for _, t := range available {
+		if t.To != nil && strings.EqualFold(t.To.Name, statusName) {
+			return c.postTransition(ctx, key, t.ID)
+		}
+		if t.To != nil {
+			names = append(names, t.To.Name)
+		}
+	}
+	return fmt.Errorf("no transition to status %q for %s; reachable: %s", statusName, key, strings.Join(names, ", "))
+}
+
+// postTransition applies the workflow transition with the given ID.
+func (c *Client) postTransition(ctx context.Context, key, transitionID string) error {
+	reqBody, err := json.Marshal(map[string]any{"transition": map[string]string{"id": transitionID}})
+	if err != nil {
+		return fmt.Errorf("encoding request: %w", err)
+	}
+
+	endpoint := c.apiPath(fmt.Sprintf("/issue/%s/transitions", url.PathEscape(key)))
+	resp, err := c.doRequest(ctx, "POST", endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return c.writeError(resp.StatusCode, body)
+	}
+	return nil
+}
+
+// assigneeField returns the assignee for a write in the shape the target
+// expects: Cloud identifies users by accountId (Issue.AssigneeID) and
+// Server/DC by username, which imports also store in Issue.AssigneeID.
+// Server/DC falls back to Issue.Assignee for issues created in bd.
+func (c *Client) assigneeField(issue *types.Issue) (map[string]string, error) {
+	if c.isCloud {
+		if issue.AssigneeID == "" {
+			return nil, fmt.Errorf("cannot assign %q on Jira Cloud: AssigneeID (accountId) is required", issue.Assignee)
+		}
+		return c.userRef(issue.AssigneeID), nil
+	}
+	if issue.AssigneeID != "" {
+		return c.userRef(issue.AssigneeID), nil
+	}
+	return c.userRef(issue.Assignee), nil
+}
+
+// userRef builds a user reference: {"accountId": id} on Cloud or
+// {"name": id} on Server/DC.
+func (c *Client) userRef(id string) map[string]string {
+	if c.isCloud {
+		return map[string]string{"accountId": id}
+	}
+	return map[string]string{"name": id}
+}
+
+// richText encodes plain text for a rich-text field: ADF for API v3, or the
+// text itself for v2, which takes wiki markup strings.
+func (c *Client) richText(text string) any {
+	if c.apiVersion == "2" {
+		return text
+	}
+	return textToADF(text)
+}
+
+// writeError builds an API error for a failed write, appending any
+// field-level validation messages Jira returned. Credentials echoed in the
+// body are redacted from both.
+func (c *Client) writeError(statusCode int, body []byte) error {
+	body = []byte(c.redact(string(body)))
+	apiErr := c.handleAPIError(statusCode, body)
+	if fieldErrs := formatFieldErrors(body); fieldErrs != "" {
+		return fmt.Errorf("%w\nField errors: %s", apiErr, fieldErrs)
+	}
+	return apiErr
+}
+
+// formatFieldErrors extracts field-level validation errors from a Jira error
+// response ({"errors": {"field": "message"}}) as "field: message" pairs.
+func formatFieldErrors(body []byte) string {
+	var errResp struct {
+		Errors map[string]string `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &errResp); err != nil || len(errResp.Errors) == 0 {
+		return ""
+	}
+
+	fieldNames := make([]string, 0, len(errResp.Errors))
+	for field := range errResp.Errors {
+		fieldNames = append(fieldNames, field)
+	}
+	sort.Strings(fieldNames)
+
+	parts := make([]string, 0, len(fieldNames))
+	for _, field := range fieldNames {
+		parts = append(parts, field+": "+errResp.Errors[field])
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,499 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCreateIssue(t *testing.T) {
+	var gotBody map[string]any
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.Method != "POST" || req.URL.Path != "/rest/api/3/issue" {
+			t.Errorf("request = %s %s, want POST /rest/api/3/issue", req.Method, req.URL.Path)
+		}
+		body, _ := io.ReadAll(req.Body)
+		if err := json.Unmarshal(body, &gotBody); err != nil {
+			t.Fatalf("request body is not JSON: %v", err)
+		}
+		return jsonResponse(http.StatusCreated, `{"id": "10042", "key": "PROJ-42", "self": "https://jira.example.com/rest/api/3/issue/10042"}`), nil
+	})
+
+	key, err := client.CreateIssue(context.Background(), &types.Issue{
+		Title:       "Fix login",
+		Description: "Users cannot log in",
+		Status:      types.StatusOpen,
+		Priority:    1,
+		IssueType:   types.TypeBug,
+		Labels:      []string{"auth"},
+	})
+	if err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+	if key != "PROJ-42" {
+		t.Errorf("CreateIssue() = %q, want PROJ-42", key)
+	}
+
+	fields, ok := gotBody["fields"].(map[string]any)
+	if !ok {
+		t.Fatalf("request body missing fields: %v", gotBody)
+	}
+	want := map[string]any{
+		"project":   map[string]any{"key": "PROJ"},
+		"summary":   "Fix login",
+		"issuetype": map[string]any{"name": "Bug"},
+		"priority":  map[string]any{"name": "High"},
+		"labels":    []any{"auth"},
+	}
+	for field, w := range want {
+		if !reflect.DeepEqual(fields[field], w) {
+			t.Errorf("fields[%q] = %v, want %v", field, fields[field], w)
+		}
+	}
+
+	desc, ok := fields["description"].(map[string]any)
+	if !ok || desc["type"] != "doc" {
+		t.Fatalf("description = %v, want ADF doc", fields["description"])
+	}
+	if got := (adfRenderer{}).render(desc); got != "Users cannot log in" {
+		t.Errorf("description text = %q, want %q", got, "Users cannot log in")
+	}
+}
+
+func TestCreateIssue_Status(t *testing.T) {
+	const transitions = `{"transitions": [
+		{"id": "11", "name": "Start Progress", "to": {"name": "In Progress"}},
+		{"id": "31", "name": "Resolve", "to": {"name": "Done"}}
+	]}`
+
+	t.Run("transitions to mapped status", func(t *testing.T) {
+		var posted string
+		client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.Path == "/rest/api/3/issue":
+				return jsonResponse(http.StatusCreated, `{"id": "10042", "key": "PROJ-42"}`), nil
+			case req.URL.Path != "/rest/api/3/issue/PROJ-42/transitions":
+				t.Errorf("unexpected path %q", req.URL.Path)
+			case req.Method == "POST":
+				body, _ := io.ReadAll(req.Body)
+				posted = string(body)
+				return jsonResponse(http.StatusNoContent, ``), nil
+			}
+			return jsonResponse(http.StatusOK, transitions), nil
+		})
+
+		key, err := client.CreateIssue(context.Background(), &types.Issue{Title: "T", Status: types.StatusClosed})
+		if err != nil {
+			t.Fatalf("CreateIssue() error = %v", err)
+		}
+		if key != "PROJ-42" {
+			t.Errorf("CreateIssue() = %q, want PROJ-42", key)
+		}
+		if posted != `{"transition":{"id":"31"}}` {
+			t.Errorf("posted body = %s, want transition 31", posted)
+		}
+	})
+
+	t.Run("open needs no transition", func(t *testing.T) {
+		client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/rest/api/3/issue" {
+				t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+			}
+			return jsonResponse(http.StatusCreated, `{"id": "10042", "key": "PROJ-42"}`), nil
+		})
+
+		if _, err := client.CreateIssue(context.Background(), &types.Issue{Title: "T", Status: types.StatusOpen}); err != nil {
+			t.Fatalf("CreateIssue() error = %v", err)
+		}
+	})
+
+	t.Run("unreachable status", func(t *testing.T) {
+		client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/rest/api/3/issue" {
+				return jsonResponse(http.StatusCreated, `{"id": "10042", "key": "PROJ-42"}`), nil
+			}
+			if req.Method == "POST" {
+				t.Error("unexpected POST for unreachable status")
+			}
+			return jsonResponse(http.StatusOK, transitions), nil
+		})
+
+		key, err := client.CreateIssue(context.Background(), &types.Issue{Title: "T", Status: types.StatusBlocked})
+		if err == nil || !strings.Contains(err.Error(), `no transition to status "Blocked"`) {
+			t.Errorf("CreateIssue() error = %v, want unreachable status", err)
+		}
+		if key != "PROJ-42" {
+			t.Errorf("CreateIssue() = %q, want the created key alongside the error", key)
+		}
+	})
+}
+
+func TestCreateIssue_FieldErrors(t *testing.T) {
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusBadRequest, `{"errorMessages": [],
+			"errors": {"summary": "You must specify a summary of the issue.", "priority": "Priority is invalid."}}`), nil
+	})
+
+	_, err := client.CreateIssue(context.Background(), &types.Issue{Priority: 2})
+	if err == nil {
+		t.Fatal("CreateIssue() expected error, got nil")
+	}
+	want := "Field errors: priority: Priority is invalid.; summary: You must specify a summary of the issue."
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("error = %q, want it to contain %q", err.Error(), want)
+	}
+}
+
+func TestCreateIssue_FieldErrorsRedacted(t *testing.T) {
+	client, err := NewClient(Config{
+		URL:      "https://jira.example.com",
+		Project:  "PROJ",
+		Username: "user",
+		APIToken: "secret-token-xyz",
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusBadRequest, `{"errorMessages": [], "errors": {"summary": "bad value secret-token-xyz"}}`), nil
+		})},
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	_, err = client.CreateIssue(context.Background(), &types.Issue{Title: "Leak", Priority: 2})
+	if err == nil {
+		t.Fatal("CreateIssue() expected error, got nil")
+	}
+	if strings.Contains(err.Error(), "secret-token-xyz") {
+		t.Errorf("error = %q, want the token redacted", err.Error())
+	}
+	if want := "Field errors: summary: bad value " + redactedMarker; !strings.Contains(err.Error(), want) {
+		t.Errorf("error = %q, want it to contain %q", err.Error(), want)
+	}
+}
+
+func TestTransitionIssue(t *testing.T) {
+	const transitions = `{"transitions": [
+		{"id": "11", "name": "To Do"},
+		{"id": "21", "name": "In Progress"},
+		{"id": "31", "name": "Done"}
+	]}`
+
+	t.Run("matching transition", func(t *testing.T) {
+		var posted string
+		client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/rest/api/3/issue/PROJ-1/transitions" {
+				t.Errorf("unexpected path %q", req.URL.Path)
+			}
+			if req.Method == "POST" {
+				body, _ := io.ReadAll(req.Body)
+				posted = string(body)
+				return jsonResponse(http.StatusNoContent, ``), nil
+			}
+			return jsonResponse(http.StatusOK, transitions), nil
+		})
+
+		if err := client.TransitionIssue(context.Background(), "PROJ-1", "done"); err != nil {
+			t.Fatalf("TransitionIssue() error = %v", err)
+		}
+		if posted != `{"transition":{"id":"31"}}` {
+			t.Errorf("posted body = %s, want transition 31", posted)
+		}
+	})
+
+	t.Run("unknown transition", func(t *testing.T) {
+		client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+			if req.Method == "POST" {
+				t.Error("unexpected POST for unknown transition")
+			}
+			return jsonResponse(http.StatusOK, transitions), nil
+		})
+
+		err := client.TransitionIssue(context.Background(), "PROJ-1", "Deployed")
+		if err == nil {
+			t.Fatal("TransitionIssue() expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "To Do, In Progress, Done") {
+			t.Errorf("error = %q, want list of available transitions", err)
+		}
+	})
+}
+
+func TestGetTransitions(t *testing.T) {
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.Method != "GET" || req.URL.Path != "/rest/api/3/issue/PROJ-1/transitions" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		return jsonResponse(http.StatusOK, `{"expand": "transitions", "transitions": [
+			{"id": "21", "name": "Start Progress", "hasScreen": false, "to": {"id": "3", "name": "In Progress", "statusCategory": {"key": "indeterminate"}}},
+			{"id": "31", "name": "Resolve", "to": {"id": "5", "name": "Done"}}
+		]}`), nil
+	})
+
+	got, err := client.GetTransitions(context.Background(), "PROJ-1")
+	if err != nil {
+		t.Fatalf("GetTransitions() error = %v", err)
+	}
+	want := []JiraTransition{
+		{ID: "21", Name: "Start Progress", To: &JiraStatus{Name: "In Progress"}},
+		{ID: "31", Name: "Resolve", To: &JiraStatus{Name: "Done"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetTransitions() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCreateIssue_APIv2PlainDescription(t *testing.T) {
+	var gotBody map[string]any
+	client, err := NewClient(Config{
+		URL:        "https://jira.example.com",
+		Project:    "PROJ",
+		APIToken:   "token",
+		APIVersion: "2",
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/rest/api/2/issue" {
+				t.Errorf("path = %q, want /rest/api/2/issue", req.URL.Path)
+			}
+			body, _ := io.ReadAll(req.Body)
+			_ = json.Unmarshal(body, &gotBody)
+			return jsonResponse(http.StatusCreated, `{"key": "PROJ-7"}`), nil
+		})},
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.CreateIssue(context.Background(), &types.Issue{Title: "T", Description: "Plain text"}); err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+	fields, _ := gotBody["fields"].(map[string]any)
+	if fields["description"] != "Plain text" {
+		t.Errorf("description = %#v, want plain string", fields["description"])
+	}
+}
+
+func TestUpdateIssue(t *testing.T) {
+	var gotMethod, gotPath string
+	var gotBody map[string]any
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		gotMethod, gotPath = req.Method, req.URL.Path
+		body, _ := io.ReadAll(req.Body)
+		if err := json.Unmarshal(body, &gotBody); err != nil {
+			t.Fatalf("request body is not JSON: %v", err)
+		}
+		return jsonResponse(http.StatusNoContent, ``), nil
+	})
+
+	fields := map[string]any{"summary": "New title", "description": "Updated text"}
+	if err := client.UpdateIssue(context.Background(), "PROJ-1", fields); err != nil {
+		t.Fatalf("UpdateIssue() error = %v", err)
+	}
+
+	if gotMethod != "PUT" || gotPath != "/rest/api/3/issue/PROJ-1" {
+		t.Errorf("request = %s %s, want PUT /rest/api/3/issue/PROJ-1", gotMethod, gotPath)
+	}
+	sent, _ := gotBody["fields"].(map[string]any)
+	if sent["summary"] != "New title" {
+		t.Errorf("summary = %v, want New title", sent["summary"])
+	}
+	desc, _ := sent["description"].(map[string]any)
+	if desc["type"] != "doc" || (adfRenderer{}).render(desc) != "Updated text" {
+		t.Errorf("description = %#v, want ADF document", sent["description"])
+	}
+	if fields["description"] != "Updated text" {
+		t.Error("UpdateIssue() modified the caller's fields map")
+	}
+}
+
+func TestUpdateIssue_FieldErrors(t *testing.T) {
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusBadRequest, `{"errorMessages": [], "errors": {"summary": "Summary is required."}}`), nil
+	})
+
+	err := client.UpdateIssue(context.Background(), "PROJ-1", map[string]any{"summary": ""})
+	if err == nil || !strings.Contains(err.Error(), "summary: Summary is required.") {
+		t.Errorf("UpdateIssue() error = %v, want field error", err)
+	}
+}
+
+func TestAddComment(t *testing.T) {
+	var gotBody map[string]any
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.Method != "POST" || req.URL.Path != "/rest/api/3/issue/PROJ-1/comment" {
+			t.Errorf("request = %s %s, want POST /rest/api/3/issue/PROJ-1/comment", req.Method, req.URL.Path)
+		}
+		body, _ := io.ReadAll(req.Body)
+		if err := json.Unmarshal(body, &gotBody); err != nil {
+			t.Fatalf("request body is not JSON: %v", err)
+		}
+		return jsonResponse(http.StatusCreated, `{"id": "10100",
+			"author": {"accountId": "abc", "displayName": "Sync Bot"},
+			"body": {"type": "doc", "version": 1, "content": [{"type": "paragraph", "content": [{"type": "text", "text": "synced from beads"}]}]},
+			"created": "2024-01-15T10:30:00.000+0000"}`), nil
+	})
+
+	comment, err := client.AddComment(context.Background(), "PROJ-1", "synced from beads")
+	if err != nil {
+		t.Fatalf("AddComment() error = %v", err)
+	}
+
+	want := map[string]any{"body": map[string]any{
+		"type":    "doc",
+		"version": float64(1),
+		"content": []any{map[string]any{
+			"type":    "paragraph",
+			"content": []any{map[string]any{"type": "text", "text": "synced from beads"}},
+		}},
+	}}
+	if !reflect.DeepEqual(gotBody, want) {
+		t.Errorf("request body = %v, want %v", gotBody, want)
+	}
+
+	if comment.ID != "10100" || comment.Author.DisplayName != "Sync Bot" {
+		t.Errorf("comment = %+v", comment)
+	}
+	if got := comment.GetBody(); got != "synced from beads" {
+		t.Errorf("GetBody() = %q, want %q", got, "synced from beads")
+	}
+}
+
+func TestAddComment_Forbidden(t *testing.T) {
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusForbidden, `{"errorMessages": ["You do not have permission to comment on this issue."]}`), nil
+	})
+
+	_, err := client.AddComment(context.Background(), "PROJ-1", "hello")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Kind != ErrorKindForbidden {
+		t.Fatalf("AddComment() error = %v, want forbidden APIError", err)
+	}
+	if !strings.Contains(err.Error(), "no permission to comment on PROJ-1") {
+		t.Errorf("error = %q, want permission hint", err)
+	}
+}
+
+func TestCreateIssue_AssigneeShape(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		issue   types.Issue
+		want    any
+		wantErr string
+	}{
+		{"cloud by accountId", "https://example.atlassian.net",
+			types.Issue{Title: "T", Assignee: "Alice Smith", AssigneeID: "5b10a2844c20165700ede21g"},
+			map[string]any{"accountId": "5b10a2844c20165700ede21g"}, ""},
+		{"server by username", "https://jira.example.com",
+			types.Issue{Title: "T", Assignee: "Alice Smith", AssigneeID: "asmith"},
+			map[string]any{"name": "asmith"}, ""},
+		{"server falls back to assignee", "https://jira.example.com",
+			types.Issue{Title: "T", Assignee: "asmith"},
+			map[string]any{"name": "asmith"}, ""},
+		{"unassigned", "https://jira.example.com", types.Issue{Title: "T"}, nil, ""},
+		{"cloud missing accountId", "https://example.atlassian.net",
+			types.Issue{Title: "T", Assignee: "Alice Smith"}, nil, "AssigneeID (accountId) is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fields map[string]any
+			client, err := NewClient(Config{
+				URL:      tt.url,
+				Project:  "PROJ",
+				Username: "user",
+				APIToken: "token",
+				HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					var body map[string]any
+					data, _ := io.ReadAll(req.Body)
+					if err := json.Unmarshal(data, &body); err != nil {
+						t.Fatalf("request body is not JSON: %v", err)
+					}
+					fields, _ = body["fields"].(map[string]any)
+					return jsonResponse(http.StatusCreated, `{"id": "10042", "key": "PROJ-42"}`), nil
+				})},
+			})
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			_, err = client.CreateIssue(context.Background(), &tt.issue)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("CreateIssue() error = %v, want %q", err, tt.wantErr)
+				}
+				if fields != nil {
+					t.Error("CreateIssue() sent a request despite the missing identifier")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateIssue() error = %v", err)
+			}
+			if !reflect.DeepEqual(fields["assignee"], tt.want) {
+				t.Errorf("assignee = %v, want %v", fields["assignee"], tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateIssue_AssigneeShape(t *testing.T) {
+	var sent map[string]any
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		var body map[string]any
+		data, _ := io.ReadAll(req.Body)
+		_ = json.Unmarshal(data, &body)
+		sent, _ = body["fields"].(map[string]any)
+		return jsonResponse(http.StatusNoContent, ``), nil
+	})
+
+	if err := client.UpdateIssue(context.Background(), "PROJ-1", map[string]any{"assignee": "asmith"}); err != nil {
+		t.Fatalf("UpdateIssue() error = %v", err)
+	}
+	if want := map[string]any{"name": "asmith"}; !reflect.DeepEqual(sent["assignee"], want) {
+		t.Errorf("assignee = %v, want %v", sent["assignee"], want)
+	}
+}
+
+func TestCreateIssue_ServerAssigneeRoundTrip(t *testing.T) {
+	// Server/DC users have a username but no accountId; the display name
+	// is not accepted as an assignee on write.
+	raw := `{
+		"key": "PROJ-1",
+		"fields": {
+			"summary": "Server issue",
+			"assignee": {"name": "asmith", "displayName": "Alice Smith"}
+		}
+	}`
+	var jira JiraIssue
+	if err := json.Unmarshal([]byte(raw), &jira); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	converter := NewConverter(ConverterConfig{JiraURL: "https://jira.example.com"})
+	issue, err := converter.ConvertOne(&jira)
+	if err != nil {
+		t.Fatalf("ConvertOne() error = %v", err)
+	}
+	if issue.Assignee != "Alice Smith" {
+		t.Errorf("Assignee = %q, want display name Alice Smith", issue.Assignee)
+	}
+
+	var fields map[string]any
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		var body map[string]any
+		data, _ := io.ReadAll(req.Body)
+		_ = json.Unmarshal(data, &body)
+		fields, _ = body["fields"].(map[string]any)
+		return jsonResponse(http.StatusCreated, `{"id": "10042", "key": "PROJ-42"}`), nil
+	})
+	if _, err := client.CreateIssue(context.Background(), issue); err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+	if want := map[string]any{"name": "asmith"}; !reflect.DeepEqual(fields["assignee"], want) {
+		t.Errorf("assignee = %v, want %v", fields["assignee"], want)
+	}
+}
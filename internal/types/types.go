@@ -42,6 +42,35 @@ type Issue struct {
 	// ===== External Integration =====
 	ExternalRef *string `json:"external_ref,omitempty"` // e.g., "gh-9", "jira-ABC"
 
+	// ===== External Tracker Metadata (set by importers, not persisted) =====
+	Sprint            string        `json:"sprint,omitempty"`             // Sprint name in the source tracker
+	SprintState       string        `json:"sprint_state,omitempty"`       // Sprint state: active|closed|future
+	Estimate          float64       `json:"estimate,omitempty"`           // Story points
+	ParentID          string        `json:"parent_id,omitempty"`          // Parent (epic or parent task) resolved from the source hierarchy
+	ParentIsEpic      bool          `json:"parent_is_epic,omitempty"`     // Parent is an epic rather than a regular issue
+	AttachmentCount   int           `json:"attachment_count,omitempty"`   // Number of attachments in the source tracker
+	Attachments       []string      `json:"attachments,omitempty"`        // Attachment filenames (metadata only)
+	Components        []string      `json:"components,omitempty"`         // Component names in the source tracker
+	FixVersions       []string      `json:"fix_versions,omitempty"`       // Releases the fix ships in
+	AffectsVersions   []string      `json:"affects_versions,omitempty"`   // Releases the problem affects
+	Milestone         string        `json:"milestone,omitempty"`          // Target release, if the importer derives one
+	AssigneeID        string        `json:"assignee_id,omitempty"`        // Stable assignee ID in the source tracker (e.g. Jira Cloud accountId or Server username)
+	ReporterID        string        `json:"reporter_id,omitempty"`        // Stable reporter ID in the source tracker
+	Watchers          []string      `json:"watchers,omitempty"`           // Display names of users watching the issue
+	VoteCount         int           `json:"vote_count,omitempty"`         // Number of votes in the source tracker
+	SecurityLevel     string        `json:"security_level,omitempty"`     // Security level restricting visibility in the source tracker
+	Environment       string        `json:"environment,omitempty"`        // Environment a bug was reported in
+	DueDate           time.Time     `json:"due_date,omitzero"`            // Due date (date only, midnight UTC)
+	IsSubtask         bool          `json:"is_subtask,omitempty"`         // Source issue type is a subtask, whatever IssueType it maps to
+	OriginalEstimate  time.Duration `json:"original_estimate,omitempty"`  // Time tracking: original estimate
+	RemainingEstimate time.Duration `json:"remaining_estimate,omitempty"` // Time tracking: remaining estimate
+	TimeSpent         time.Duration `json:"time_spent,omitempty"`         // Time tracking: total time logged
+	EpicName          string        `json:"epic_name,omitempty"`          // Short epic name from the source tracker
+	Team              string        `json:"team,omitempty"`               // Team the issue is routed to in the source tracker
+	Rank              string        `json:"rank,omitempty"`               // Backlog rank in the source tracker (e.g. a Jira LexoRank)
+	Flagged           bool          `json:"flagged,omitempty"`            // Flagged as an impediment in the source tracker
+	SourceStatus      string        `json:"source_status,omitempty"`      // Status name in the source tracker, before mapping
+
 	// ===== Compaction Metadata =====
 	CompactionLevel   int        `json:"compaction_level,omitempty"`
 	CompactedAt       *time.Time `json:"compacted_at,omitempty"`